@@ -0,0 +1,468 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrAPNSBadDeviceToken is returned (wrapped in a NotificationError) when APNs
+	// rejects a device token as malformed or issued for another environment
+	ErrAPNSBadDeviceToken = errors.New("apns device token is invalid")
+
+	// ErrAPNSUnregistered is returned (wrapped in a NotificationError) when the device
+	// token is no longer active for the topic and should be removed
+	ErrAPNSUnregistered = errors.New("apns device token is unregistered")
+)
+
+// apnsTokenLifetime is how long a provider token is reused; Apple rejects tokens
+// older than one hour and throttles refreshes more frequent than every 20 minutes
+const apnsTokenLifetime = 50 * time.Minute
+
+// apnsReservedMetadata lists metadata keys that are mapped to aps fields or headers
+// rather than custom payload keys; "aps" would replace the built aps dictionary
+var apnsReservedMetadata = map[string]bool{
+	"aps":         true,
+	"sound":       true,
+	"badge":       true,
+	"collapse_id": true,
+	"expiration":  true,
+	"thread_id":   true,
+	"category":    true,
+}
+
+// APNSNotifier sends push notifications via the Apple Push Notification service
+type APNSNotifier struct {
+	name         string
+	key          *ecdsa.PrivateKey
+	keyID        string
+	teamID       string
+	topic        string
+	endpoint     string
+	defaultToken string
+	client       *http.Client
+
+	// providerToken is the cached JWT used for authentication
+	providerToken string
+	tokenIssuedAt time.Time
+	mu            sync.Mutex
+}
+
+// APNSConfig holds configuration for Apple Push Notification service notifications
+type APNSConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "apns")
+	Name string
+
+	// AuthKey is the content of the .p8 signing key
+	AuthKey []byte
+
+	// AuthKeyFile is the path to the .p8 signing key (alternative to AuthKey)
+	AuthKeyFile string
+
+	// KeyID is the 10-character key identifier of the signing key
+	KeyID string
+
+	// TeamID is the 10-character Apple developer team identifier
+	TeamID string
+
+	// Topic is the app bundle ID
+	Topic string
+
+	// Production selects the production endpoint (default is the sandbox endpoint)
+	Production bool
+
+	// Endpoint overrides the APNs endpoint (optional, for testing)
+	Endpoint string
+
+	// DefaultDeviceToken is the default device token (optional)
+	DefaultDeviceToken string
+
+	// HTTPClient allows custom HTTP client (optional, must support HTTP/2)
+	HTTPClient *http.Client
+}
+
+type apnsErrorResponse struct {
+	Reason    string `json:"reason"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+// NewAPNSNotifier creates a new APNs notifier
+func NewAPNSNotifier(config APNSConfig) (*APNSNotifier, error) {
+	authKey := config.AuthKey
+	if len(authKey) == 0 && config.AuthKeyFile != "" {
+		data, err := os.ReadFile(config.AuthKeyFile)
+		if err != nil {
+			return nil, &NotificationError{
+				Provider: "apns",
+				Message:  "failed to read auth key file",
+				Err:      err,
+			}
+		}
+		authKey = data
+	}
+
+	if len(authKey) == 0 {
+		return nil, &NotificationError{
+			Provider: "apns",
+			Message:  "auth key is required",
+		}
+	}
+
+	if config.KeyID == "" || config.TeamID == "" {
+		return nil, &NotificationError{
+			Provider: "apns",
+			Message:  "key ID and team ID are required",
+		}
+	}
+
+	if config.Topic == "" {
+		return nil, &NotificationError{
+			Provider: "apns",
+			Message:  "topic (bundle ID) is required",
+		}
+	}
+
+	key, err := parseECPrivateKey(authKey)
+	if err != nil {
+		return nil, &NotificationError{
+			Provider: "apns",
+			Message:  "failed to parse auth key",
+			Err:      err,
+		}
+	}
+
+	// Provider tokens are signed with ES256, which requires a P-256 key
+	if key.Curve != elliptic.P256() {
+		return nil, &NotificationError{
+			Provider: "apns",
+			Message:  fmt.Sprintf("auth key must use the P-256 curve, got %s", key.Curve.Params().Name),
+		}
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = "https://api.sandbox.push.apple.com"
+		if config.Production {
+			endpoint = "https://api.push.apple.com"
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "apns"
+	}
+
+	return &APNSNotifier{
+		name:         name,
+		key:          key,
+		keyID:        config.KeyID,
+		teamID:       config.TeamID,
+		topic:        config.Topic,
+		endpoint:     strings.TrimRight(endpoint, "/"),
+		defaultToken: config.DefaultDeviceToken,
+		client:       newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (a *APNSNotifier) Name() string {
+	return a.name
+}
+
+// Send sends an alert with the given body to the default device token
+func (a *APNSNotifier) Send(ctx context.Context, message string) error {
+	return a.SendWithOptions(ctx, &Message{
+		Text:    message,
+		Channel: a.defaultToken,
+	})
+}
+
+// SendWithOptions sends an alert notification.
+// Channel is the device token. Metadata keys "sound", "badge", "thread_id" and
+// "category" map to aps fields, "collapse_id" and "expiration" (time.Time or Unix
+// seconds) to request headers; remaining keys are added as custom payload data.
+func (a *APNSNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if msg.Text == "" && msg.Title == "" {
+		return &NotificationError{
+			Provider: "apns",
+			Message:  "message text is required",
+		}
+	}
+
+	alert := map[string]interface{}{
+		"body": msg.Text,
+	}
+	if msg.Title != "" {
+		alert["title"] = msg.Title
+	}
+
+	interruptionLevel, priority := apnsInterruptionLevel(msg.Priority)
+
+	aps := map[string]interface{}{
+		"alert":              alert,
+		"interruption-level": interruptionLevel,
+	}
+
+	if sound := metadataString(msg, "sound"); sound != "" {
+		aps["sound"] = sound
+	}
+	if badge, ok := metadataInt(msg, "badge"); ok {
+		aps["badge"] = badge
+	}
+	if threadID := metadataString(msg, "thread_id"); threadID != "" {
+		aps["thread-id"] = threadID
+	}
+	if category := metadataString(msg, "category"); category != "" {
+		aps["category"] = category
+	}
+
+	payload := map[string]interface{}{
+		"aps": aps,
+	}
+	for key, value := range msg.Metadata {
+		if !apnsReservedMetadata[key] {
+			payload[key] = value
+		}
+	}
+
+	header := http.Header{}
+	header.Set("apns-push-type", "alert")
+	header.Set("apns-priority", priority)
+
+	if collapseID := metadataString(msg, "collapse_id"); collapseID != "" {
+		header.Set("apns-collapse-id", collapseID)
+	}
+
+	switch expiration := msg.Metadata["expiration"].(type) {
+	case time.Time:
+		header.Set("apns-expiration", strconv.FormatInt(expiration.Unix(), 10))
+	case nil:
+	default:
+		if seconds, ok := metadataInt(msg, "expiration"); ok {
+			header.Set("apns-expiration", strconv.Itoa(seconds))
+		}
+	}
+
+	_, err := a.push(ctx, msg.Channel, header, payload)
+	return err
+}
+
+// SendRichMessage sends a raw payload.
+// blocks must be a map[string]interface{} containing the full payload (including "aps");
+// channel is the device token.
+func (a *APNSNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	payload, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "apns",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	if _, ok := payload["aps"]; !ok {
+		return &NotificationError{
+			Provider: "apns",
+			Message:  "payload must contain an aps dictionary",
+		}
+	}
+
+	header := http.Header{}
+	header.Set("apns-push-type", "alert")
+
+	_, err := a.push(ctx, channel, header, payload)
+	return err
+}
+
+// push sends payload to a device token and returns the apns-id of the notification
+func (a *APNSNotifier) push(ctx context.Context, deviceToken string, header http.Header, payload interface{}) (string, error) {
+	if deviceToken == "" {
+		deviceToken = a.defaultToken
+	}
+
+	if deviceToken == "" {
+		return "", &NotificationError{
+			Provider: "apns",
+			Message:  "device token is required",
+		}
+	}
+
+	token, err := a.token()
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "apns",
+			Message:  "failed to marshal request",
+			Err:      err,
+		}
+	}
+
+	endpoint := a.endpoint + "/3/device/" + url.PathEscape(deviceToken)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "apns",
+			Message:  "failed to create request",
+			Err:      err,
+		}
+	}
+
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("authorization", "bearer "+token)
+	req.Header.Set("apns-topic", a.topic)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "apns",
+			Message:  "failed to send request",
+			Err:      err,
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusOK {
+		return resp.Header.Get("apns-id"), nil
+	}
+
+	var result apnsErrorResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", &NotificationError{
+			Provider: "apns",
+			Message:  fmt.Sprintf("API request failed with status %d", resp.StatusCode),
+		}
+	}
+
+	if result.Reason == "ExpiredProviderToken" || result.Reason == "InvalidProviderToken" {
+		// Sign a new provider token on the next send rather than reusing the rejected one
+		a.mu.Lock()
+		if a.providerToken == token {
+			a.providerToken = ""
+		}
+		a.mu.Unlock()
+	}
+
+	return "", apnsError(resp.StatusCode, result.Reason)
+}
+
+// token returns the cached provider token, signing a new one when it is too old or was rejected
+func (a *APNSNotifier) token() (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.providerToken != "" && time.Since(a.tokenIssuedAt) < apnsTokenLifetime {
+		return a.providerToken, nil
+	}
+
+	now := time.Now()
+	signingInput, err := jwtSigningInput(
+		map[string]string{"alg": "ES256", "kid": a.keyID},
+		map[string]interface{}{"iss": a.teamID, "iat": now.Unix()},
+	)
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "apns",
+			Message:  "failed to build provider token",
+			Err:      err,
+		}
+	}
+
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, a.key, digest[:])
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "apns",
+			Message:  "failed to sign provider token",
+			Err:      err,
+		}
+	}
+
+	// ES256 signatures are the fixed-size concatenation of r and s
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	a.providerToken = signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	a.tokenIssuedAt = now
+
+	return a.providerToken, nil
+}
+
+// apnsError converts an APNs error reason into a NotificationError,
+// wrapping the sentinel errors for invalid and unregistered device tokens
+func apnsError(status int, reason string) error {
+	err := &NotificationError{
+		Provider: "apns",
+		Message:  fmt.Sprintf("API request failed with status %d: %s", status, reason),
+	}
+
+	switch reason {
+	case "BadDeviceToken", "DeviceTokenNotForTopic":
+		err.Err = ErrAPNSBadDeviceToken
+	case "Unregistered", "ExpiredToken":
+		err.Err = ErrAPNSUnregistered
+	}
+
+	return err
+}
+
+// apnsInterruptionLevel maps a message priority to an interruption level and apns-priority
+func apnsInterruptionLevel(priority string) (string, string) {
+	switch priority {
+	case PriorityHigh:
+		return "time-sensitive", "10"
+	case PriorityLow:
+		return "passive", "5"
+	default:
+		return "active", "10"
+	}
+}
+
+// parseECPrivateKey parses a PEM encoded PKCS#8 or SEC 1 EC private key (such as a .p8 file)
+func parseECPrivateKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := parsed.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an EC key")
+	}
+
+	return key, nil
+}
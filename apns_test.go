@@ -0,0 +1,190 @@
+package notify
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestAPNSKey(t *testing.T) []byte {
+	t.Helper()
+	return newTestECKey(t, elliptic.P256())
+}
+
+// newTestECKey returns a PKCS#8 PEM encoded key on the given curve
+func newTestECKey(t *testing.T, curve elliptic.Curve) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func TestNewAPNSNotifier(t *testing.T) {
+	_, err := NewAPNSNotifier(APNSConfig{AuthKey: newTestAPNSKey(t), KeyID: "KEY", TeamID: "TEAM"})
+	if err == nil {
+		t.Error("Expected error when topic is missing")
+	}
+
+	_, err = NewAPNSNotifier(APNSConfig{AuthKey: newTestECKey(t, elliptic.P384()), KeyID: "KEY", TeamID: "TEAM", Topic: "com.example.app"})
+	if err == nil || !strings.Contains(err.Error(), "P-256") {
+		t.Errorf("Expected error for a P-384 key, got %v", err)
+	}
+
+	notifier, err := NewAPNSNotifier(APNSConfig{AuthKey: newTestAPNSKey(t), KeyID: "KEY", TeamID: "TEAM", Topic: "com.example.app"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "apns" {
+		t.Errorf("Expected name 'apns', got '%s'", notifier.Name())
+	}
+}
+
+func TestAPNSSendOverHTTP2(t *testing.T) {
+	var payload map[string]interface{}
+	var header http.Header
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			t.Errorf("Expected HTTP/2 request, got %s", r.Proto)
+		}
+
+		if strings.HasSuffix(r.URL.Path, "/bad-token") {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"reason":"BadDeviceToken"}`))
+			return
+		}
+		if strings.HasSuffix(r.URL.Path, "/old-token") {
+			w.WriteHeader(http.StatusGone)
+			_, _ = w.Write([]byte(`{"reason":"Unregistered","timestamp":1700000000000}`))
+			return
+		}
+
+		header = r.Header.Clone()
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Failed to decode payload: %v", err)
+		}
+		w.Header().Set("apns-id", "id-1")
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	notifier, err := NewAPNSNotifier(APNSConfig{
+		AuthKey:    newTestAPNSKey(t),
+		KeyID:      "KEY",
+		TeamID:     "TEAM",
+		Topic:      "com.example.app",
+		Endpoint:   server.URL,
+		HTTPClient: server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	ctx := context.Background()
+	err = notifier.SendWithOptions(ctx, &Message{
+		Title:    "Payment received",
+		Text:     "You received $10",
+		Priority: PriorityHigh,
+		Channel:  "device-token",
+		Metadata: map[string]interface{}{
+			"sound":       "default",
+			"badge":       3,
+			"collapse_id": "payments",
+			"expiration":  1700000000,
+			"payment_id":  "p-1",
+			"aps":         "ignored",
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if header.Get("apns-topic") != "com.example.app" || header.Get("apns-collapse-id") != "payments" ||
+		header.Get("apns-expiration") != "1700000000" || header.Get("apns-priority") != "10" {
+		t.Errorf("Unexpected headers: %v", header)
+	}
+
+	if !strings.HasPrefix(header.Get("authorization"), "bearer ") {
+		t.Errorf("Expected bearer provider token, got %s", header.Get("authorization"))
+	}
+
+	aps := payload["aps"].(map[string]interface{})
+	if aps["interruption-level"] != "time-sensitive" || aps["badge"] != float64(3) || aps["sound"] != "default" {
+		t.Errorf("Unexpected aps: %v", aps)
+	}
+
+	if payload["payment_id"] != "p-1" {
+		t.Errorf("Expected custom data payment_id 'p-1', got %v", payload["payment_id"])
+	}
+
+	err = notifier.SendWithOptions(ctx, &Message{Text: "hi", Channel: "bad-token"})
+	if !errors.Is(err, ErrAPNSBadDeviceToken) {
+		t.Errorf("Expected ErrAPNSBadDeviceToken, got %v", err)
+	}
+
+	err = notifier.SendWithOptions(ctx, &Message{Text: "hi", Channel: "old-token"})
+	if !errors.Is(err, ErrAPNSUnregistered) {
+		t.Errorf("Expected ErrAPNSUnregistered, got %v", err)
+	}
+}
+
+func TestAPNSRenewsRejectedProviderToken(t *testing.T) {
+	var tokens []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("authorization"))
+		if len(tokens) == 1 {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"reason":"ExpiredProviderToken"}`))
+			return
+		}
+		w.Header().Set("apns-id", "id-1")
+	}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	defer server.Close()
+
+	notifier, err := NewAPNSNotifier(APNSConfig{
+		AuthKey:            newTestAPNSKey(t),
+		KeyID:              "KEY",
+		TeamID:             "TEAM",
+		Topic:              "com.example.app",
+		Endpoint:           server.URL,
+		DefaultDeviceToken: "device-token",
+		HTTPClient:         server.Client(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := notifier.Send(ctx, "hi"); err == nil || !strings.Contains(err.Error(), "ExpiredProviderToken") {
+		t.Fatalf("Expected ExpiredProviderToken error, got %v", err)
+	}
+	if err := notifier.Send(ctx, "hi"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if len(tokens) != 2 || tokens[0] == tokens[1] {
+		t.Errorf("Expected a new provider token after rejection, got %v", tokens)
+	}
+}
@@ -0,0 +1,226 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ANSI escape codes used by the console notifier
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiDim    = "\033[2m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiCyan   = "\033[36m"
+)
+
+// ConsoleNotifier prints notifications to a terminal, for local development
+type ConsoleNotifier struct {
+	name   string
+	writer io.Writer
+	color  bool
+	mu     sync.Mutex
+}
+
+// ConsoleConfig holds configuration for console notifications
+type ConsoleConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "console").
+	// Set it to a production provider name (e.g., "slack") to swap providers in development.
+	Name string
+
+	// Writer is where notifications are printed (optional, defaults to os.Stdout)
+	Writer io.Writer
+
+	// DisableColor turns off ANSI colors (optional)
+	DisableColor bool
+}
+
+// NewConsoleNotifier creates a new console notifier
+func NewConsoleNotifier(config ConsoleConfig) (*ConsoleNotifier, error) {
+	name := config.Name
+	if name == "" {
+		name = "console"
+	}
+
+	writer := config.Writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+
+	return &ConsoleNotifier{
+		name:   name,
+		writer: writer,
+		color:  !config.DisableColor,
+	}, nil
+}
+
+// Name returns the name of the provider
+func (c *ConsoleNotifier) Name() string {
+	return c.name
+}
+
+// Send prints a simple text message
+func (c *ConsoleNotifier) Send(ctx context.Context, message string) error {
+	return c.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions prints the full message including attachments and metadata
+func (c *ConsoleNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if err := ctx.Err(); err != nil {
+		return &NotificationError{
+			Provider: c.name,
+			Message:  "context done",
+			Err:      err,
+		}
+	}
+
+	var sb strings.Builder
+
+	priority := msg.Priority
+	if priority == "" {
+		priority = PriorityNormal
+	}
+
+	fmt.Fprintf(&sb, "%s %s",
+		c.paint(ansiDim, time.Now().Format("2006-01-02 15:04:05")),
+		c.paint(consolePriorityColor(priority), fmt.Sprintf("%-6s", strings.ToUpper(priority))))
+	if msg.Channel != "" {
+		fmt.Fprintf(&sb, " %s", c.paint(ansiCyan, msg.Channel))
+	}
+	sb.WriteString("\n")
+
+	if msg.Title != "" {
+		fmt.Fprintf(&sb, "  %s\n", c.paint(ansiBold, msg.Title))
+	}
+	if msg.Text != "" {
+		sb.WriteString(indentLines(msg.Text, "  "))
+	}
+
+	for _, att := range msg.Attachments {
+		bar := c.paint(consoleAttachmentColor(att.Color), "┃")
+		if att.Title != "" {
+			fmt.Fprintf(&sb, "  %s %s\n", bar, c.paint(ansiBold, att.Title))
+		}
+		if att.Text != "" {
+			sb.WriteString(indentLines(att.Text, "  "+bar+" "))
+		}
+		for _, field := range att.Fields {
+			fmt.Fprintf(&sb, "  %s %s: %s\n", bar, c.paint(ansiDim, field.Title), field.Value)
+		}
+		if att.ImageURL != "" {
+			fmt.Fprintf(&sb, "  %s %s\n", bar, att.ImageURL)
+		}
+		if att.Footer != "" {
+			fmt.Fprintf(&sb, "  %s %s\n", bar, c.paint(ansiDim, att.Footer))
+		}
+	}
+
+	if len(msg.Metadata) > 0 {
+		keys := make([]string, 0, len(msg.Metadata))
+		for key := range msg.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		pairs := make([]string, len(keys))
+		for i, key := range keys {
+			pairs[i] = fmt.Sprintf("%s=%v", key, msg.Metadata[key])
+		}
+		fmt.Fprintf(&sb, "  %s\n", c.paint(ansiDim, strings.Join(pairs, " ")))
+	}
+
+	return c.write(sb.String())
+}
+
+// SendRichMessage prints the rich message blocks as indented JSON
+func (c *ConsoleNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return &NotificationError{
+			Provider: c.name,
+			Message:  "context done",
+			Err:      err,
+		}
+	}
+
+	data, err := json.MarshalIndent(blocks, "  ", "  ")
+	if err != nil {
+		data = []byte(fmt.Sprintf("%v", blocks))
+	}
+
+	header := fmt.Sprintf("%s %s", c.paint(ansiDim, time.Now().Format("2006-01-02 15:04:05")), c.paint(ansiBold, "RICH"))
+	if channel != "" {
+		header += " " + c.paint(ansiCyan, channel)
+	}
+
+	return c.write(header + "\n  " + string(data) + "\n")
+}
+
+// write writes output in a single call so concurrent notifications don't interleave
+func (c *ConsoleNotifier) write(output string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, err := io.WriteString(c.writer, output); err != nil {
+		return &NotificationError{
+			Provider: c.name,
+			Message:  "failed to write message",
+			Err:      err,
+		}
+	}
+
+	return nil
+}
+
+// paint wraps text in an ANSI color code when colors are enabled
+func (c *ConsoleNotifier) paint(code, text string) string {
+	if !c.color {
+		return text
+	}
+	return code + text + ansiReset
+}
+
+// consolePriorityColor returns the color used for a priority label
+func consolePriorityColor(priority string) string {
+	switch priority {
+	case PriorityHigh:
+		return ansiRed + ansiBold
+	case PriorityLow:
+		return ansiDim
+	default:
+		return ansiGreen
+	}
+}
+
+// consoleAttachmentColor maps Slack-style attachment colors to ANSI colors
+func consoleAttachmentColor(color string) string {
+	switch color {
+	case "danger", "#ff0000", "#FF0000":
+		return ansiRed
+	case "warning":
+		return ansiYellow
+	case "good":
+		return ansiGreen
+	default:
+		return ansiDim
+	}
+}
+
+// indentLines prefixes every line of text and terminates it with a newline
+func indentLines(text, prefix string) string {
+	lines := strings.Split(strings.TrimRight(text, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
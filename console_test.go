@@ -0,0 +1,45 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestConsoleNotifierOutput(t *testing.T) {
+	var buf bytes.Buffer
+	notifier, err := NewConsoleNotifier(ConsoleConfig{Writer: &buf, DisableColor: true})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "console" {
+		t.Errorf("Expected name 'console', got '%s'", notifier.Name())
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Title:    "Disk full",
+		Text:     "line one\nline two",
+		Priority: PriorityHigh,
+		Channel:  "#alerts",
+		Attachments: []Attachment{
+			{Title: "Host", Fields: []Field{{Title: "Name", Value: "db-1"}}},
+		},
+		Metadata: map[string]interface{}{"b": 2, "a": "x"},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	output := buf.String()
+	for _, want := range []string{"HIGH", "#alerts", "  Disk full\n", "  line one\n  line two\n", "Name: db-1", "a=x b=2"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, output)
+		}
+	}
+
+	if strings.Contains(output, "\033[") {
+		t.Error("Expected no ANSI codes when colors are disabled")
+	}
+}
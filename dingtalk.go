@@ -0,0 +1,201 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DingTalkNotifier sends notifications to DingTalk group robots
+type DingTalkNotifier struct {
+	name       string
+	webhookURL string
+	secret     string
+	client     *http.Client
+}
+
+// DingTalkConfig holds configuration for DingTalk notifications
+type DingTalkConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "dingtalk")
+	Name string
+
+	// WebhookURL is the robot webhook URL (including access_token)
+	WebhookURL string
+
+	// Secret is the signing secret when the robot uses "additional signature" security (optional)
+	Secret string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type dingTalkResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// NewDingTalkNotifier creates a new DingTalk notifier
+func NewDingTalkNotifier(config DingTalkConfig) (*DingTalkNotifier, error) {
+	if config.WebhookURL == "" {
+		return nil, &NotificationError{
+			Provider: "dingtalk",
+			Message:  "webhook URL is required",
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "dingtalk"
+	}
+
+	return &DingTalkNotifier{
+		name:       name,
+		webhookURL: config.WebhookURL,
+		secret:     config.Secret,
+		client:     newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (d *DingTalkNotifier) Name() string {
+	return d.name
+}
+
+// Send sends a simple text message
+func (d *DingTalkNotifier) Send(ctx context.Context, message string) error {
+	return d.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Messages with a title or attachments are sent as markdown. Metadata keys
+// "mention_mobiles", "mention_users" and "mention_all" @mention group members.
+func (d *DingTalkNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if msg.Text == "" {
+		return &NotificationError{
+			Provider: "dingtalk",
+			Message:  "message text is required",
+		}
+	}
+
+	mobiles := metadataStrings(msg, "mention_mobiles")
+	users := metadataStrings(msg, "mention_users")
+	mentionAll, _ := msg.Metadata["mention_all"].(bool)
+
+	// DingTalk only highlights mentions that also appear in the message text
+	var mentions []string
+	for _, mobile := range mobiles {
+		mentions = append(mentions, "@"+mobile)
+	}
+	for _, user := range users {
+		mentions = append(mentions, "@"+user)
+	}
+
+	payload := map[string]interface{}{
+		"at": map[string]interface{}{
+			"atMobiles": mobiles,
+			"atUserIds": users,
+			"isAtAll":   mentionAll,
+		},
+	}
+
+	if msg.Title == "" && len(msg.Attachments) == 0 {
+		content := msg.Text
+		if len(mentions) > 0 {
+			content += " " + strings.Join(mentions, " ")
+		}
+		payload["msgtype"] = "text"
+		payload["text"] = map[string]string{"content": content}
+	} else {
+		title := msg.Title
+		if title == "" {
+			title = msg.Text
+		}
+
+		var sb strings.Builder
+		if msg.Title != "" {
+			fmt.Fprintf(&sb, "### %s\n\n", msg.Title)
+		}
+		sb.WriteString(msg.Text)
+		if len(msg.Attachments) > 0 {
+			sb.WriteString("\n\n")
+			sb.WriteString(strings.ReplaceAll(markdownAttachments(msg.Attachments), "\n", "\n\n"))
+		}
+		if len(mentions) > 0 {
+			sb.WriteString("\n\n")
+			sb.WriteString(strings.Join(mentions, " "))
+		}
+
+		payload["msgtype"] = "markdown"
+		payload["markdown"] = map[string]string{"title": title, "text": sb.String()}
+	}
+
+	return d.post(ctx, msg.Channel, payload)
+}
+
+// SendRichMessage sends a raw robot message.
+// blocks must be a map[string]interface{} with "msgtype" and the matching content
+// (e.g., actionCard or feedCard); channel, if set, overrides the webhook URL.
+func (d *DingTalkNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	payload, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "dingtalk",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	if _, ok := payload["msgtype"]; !ok {
+		return &NotificationError{
+			Provider: "dingtalk",
+			Message:  "msgtype is required",
+		}
+	}
+
+	return d.post(ctx, channel, payload)
+}
+
+// post signs the webhook URL (if a secret is configured) and sends payload
+func (d *DingTalkNotifier) post(ctx context.Context, webhookURL string, payload interface{}) error {
+	if webhookURL == "" {
+		webhookURL = d.webhookURL
+	}
+
+	if d.secret != "" {
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		separator := "?"
+		if strings.Contains(webhookURL, "?") {
+			separator = "&"
+		}
+		webhookURL += separator + "timestamp=" + timestamp + "&sign=" + url.QueryEscape(dingTalkSign(timestamp, d.secret))
+	}
+
+	var result dingTalkResponse
+	if err := sendJSON(ctx, d.client, "dingtalk", http.MethodPost, webhookURL, nil, payload, &result); err != nil {
+		return err
+	}
+
+	if result.ErrCode != 0 {
+		return &NotificationError{
+			Provider: "dingtalk",
+			Message:  fmt.Sprintf("API returned error %d: %s", result.ErrCode, result.ErrMsg),
+		}
+	}
+
+	return nil
+}
+
+// dingTalkSign computes the robot signature: base64(HMAC-SHA256(secret, timestamp + "\n" + secret))
+func dingTalkSign(timestamp, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,104 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewDingTalkNotifier(t *testing.T) {
+	_, err := NewDingTalkNotifier(DingTalkConfig{})
+	if err == nil {
+		t.Error("Expected error when webhook URL is missing")
+	}
+
+	notifier, err := NewDingTalkNotifier(DingTalkConfig{WebhookURL: "https://oapi.dingtalk.com/robot/send?access_token=x"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "dingtalk" {
+		t.Errorf("Expected name 'dingtalk', got '%s'", notifier.Name())
+	}
+}
+
+func TestDingTalkSign(t *testing.T) {
+	// base64(HMAC-SHA256(key "SECsecret", "1700000000000\nSECsecret")), computed independently
+	want := "0QWYb8Ux63Sm4BhHaJNL3lv5mqW1sLFoks7vu+HFFi4="
+	if got := dingTalkSign("1700000000000", "SECsecret"); got != want {
+		t.Errorf("Expected signature %s, got %s", want, got)
+	}
+}
+
+func TestDingTalkSignedMarkdown(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("access_token") != "token" {
+			t.Errorf("Expected access token to be kept, got %s", r.URL.RawQuery)
+		}
+		// dingTalkSign itself is checked against a known signature in TestDingTalkSign
+		if query.Get("sign") != dingTalkSign(query.Get("timestamp"), "SECsecret") {
+			t.Errorf("Invalid signature for timestamp %s", query.Get("timestamp"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewDingTalkNotifier(DingTalkConfig{
+		WebhookURL: server.URL + "/robot/send?access_token=token",
+		Secret:     "SECsecret",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Title: "Release",
+		Text:  "v2.0 deployed",
+		Attachments: []Attachment{
+			{Fields: []Field{{Title: "Env", Value: "prod"}}},
+		},
+		Metadata: map[string]interface{}{"mention_mobiles": []string{"13800000000"}},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if body["msgtype"] != "markdown" {
+		t.Errorf("Expected markdown message, got %v", body["msgtype"])
+	}
+
+	markdown := body["markdown"].(map[string]interface{})
+	text := markdown["text"].(string)
+	if !strings.Contains(text, "### Release") || !strings.Contains(text, "**Env:** prod") || !strings.Contains(text, "@13800000000") {
+		t.Errorf("Unexpected markdown text: %s", text)
+	}
+
+	at := body["at"].(map[string]interface{})
+	if len(at["atMobiles"].([]interface{})) != 1 {
+		t.Errorf("Expected 1 mentioned mobile, got %v", at["atMobiles"])
+	}
+}
+
+func TestDingTalkAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errcode":310000,"errmsg":"sign not match"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewDingTalkNotifier(DingTalkConfig{WebhookURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if err := notifier.Send(context.Background(), "hello"); err == nil {
+		t.Error("Expected error when errcode is non-zero")
+	}
+}
@@ -0,0 +1,197 @@
+package notify
+
+import (
+	"fmt"
+	"html"
+	"mime"
+	"net/mail"
+	"path/filepath"
+	"strings"
+)
+
+// emailSubjectLimit is the maximum subject length derived from message text
+const emailSubjectLimit = 78
+
+// emailColors maps Slack-style attachment colors to CSS colors
+var emailColors = map[string]string{
+	"good":    "#2eb67d",
+	"warning": "#ecb22e",
+	"danger":  "#e01e5a",
+}
+
+// EmailFile is a file attached to an email.
+// Pass files in Message.Metadata["email_files"] as an EmailFile or []EmailFile.
+type EmailFile struct {
+	// Filename is the name shown to the recipient
+	Filename string
+
+	// ContentType is the MIME type (optional, detected from the filename)
+	ContentType string
+
+	// Content is the raw file content
+	Content []byte
+}
+
+// emailContent is the provider-independent rendering of a Message as an email
+type emailContent struct {
+	To      []string
+	Cc      []string
+	Bcc     []string
+	ReplyTo string
+	Subject string
+	Text    string
+	HTML    string
+	Headers map[string]string
+	Files   []EmailFile
+}
+
+// renderEmail renders msg as an email.
+// Recipients come from Message.Channel (comma-separated) or defaultTo, and
+// Metadata keys "cc", "bcc" and "reply_to" add further addresses.
+func renderEmail(provider string, msg *Message, defaultTo []string) (*emailContent, error) {
+	if msg.Text == "" {
+		return nil, &NotificationError{
+			Provider: provider,
+			Message:  "message text is required",
+		}
+	}
+
+	to := defaultTo
+	if msg.Channel != "" {
+		to = splitList(msg.Channel)
+	}
+	if len(to) == 0 {
+		return nil, &NotificationError{
+			Provider: provider,
+			Message:  "at least one recipient is required",
+		}
+	}
+
+	content := &emailContent{
+		To:      to,
+		Cc:      metadataStrings(msg, "cc"),
+		Bcc:     metadataStrings(msg, "bcc"),
+		ReplyTo: metadataString(msg, "reply_to"),
+		Subject: emailSubject(msg),
+		Text:    msg.Text,
+		HTML:    emailHTML(msg),
+		Headers: map[string]string{},
+	}
+
+	if len(msg.Attachments) > 0 {
+		content.Text += "\n\n" + plainTextAttachments(msg.Attachments)
+	}
+
+	switch msg.Priority {
+	case PriorityHigh:
+		content.Headers["X-Priority"] = "1"
+		content.Headers["Importance"] = "high"
+	case PriorityLow:
+		content.Headers["X-Priority"] = "5"
+		content.Headers["Importance"] = "low"
+	}
+
+	switch files := msg.Metadata["email_files"].(type) {
+	case EmailFile:
+		content.Files = []EmailFile{files}
+	case []EmailFile:
+		// Copy so filling in content types doesn't modify the caller's metadata
+		content.Files = append([]EmailFile(nil), files...)
+	}
+	for i, file := range content.Files {
+		if file.Filename == "" {
+			return nil, &NotificationError{
+				Provider: provider,
+				Message:  fmt.Sprintf("email file %d has no filename", i),
+			}
+		}
+		if file.ContentType == "" {
+			content.Files[i].ContentType = emailContentType(file.Filename)
+		}
+	}
+
+	return content, nil
+}
+
+// emailSubject returns the title, or the first line of the text shortened to a subject
+func emailSubject(msg *Message) string {
+	subject := msg.Title
+	if subject == "" {
+		subject, _, _ = strings.Cut(msg.Text, "\n")
+	}
+
+	subject = strings.Join(strings.Fields(subject), " ")
+	if runes := []rune(subject); len(runes) > emailSubjectLimit {
+		subject = string(runes[:emailSubjectLimit-3]) + "..."
+	}
+	return subject
+}
+
+// emailHTML renders the message title, text and attachments as a simple HTML document
+func emailHTML(msg *Message) string {
+	var sb strings.Builder
+
+	sb.WriteString(`<!DOCTYPE html><html><body style="font-family:sans-serif;font-size:14px;color:#1d1c1d">`)
+	if msg.Title != "" {
+		fmt.Fprintf(&sb, `<h2 style="margin:0 0 12px">%s</h2>`, html.EscapeString(msg.Title))
+	}
+	fmt.Fprintf(&sb, `<p>%s</p>`, emailHTMLText(msg.Text))
+
+	for _, att := range msg.Attachments {
+		color := att.Color
+		if mapped, ok := emailColors[color]; ok {
+			color = mapped
+		}
+		if color == "" {
+			color = "#dddddd"
+		}
+
+		fmt.Fprintf(&sb, `<div style="border-left:4px solid %s;padding:4px 12px;margin:12px 0">`, html.EscapeString(color))
+		if att.Title != "" {
+			fmt.Fprintf(&sb, `<p><strong>%s</strong></p>`, html.EscapeString(att.Title))
+		}
+		if att.Text != "" {
+			fmt.Fprintf(&sb, `<p>%s</p>`, emailHTMLText(att.Text))
+		}
+		if len(att.Fields) > 0 {
+			sb.WriteString(`<table cellpadding="4">`)
+			for _, field := range att.Fields {
+				fmt.Fprintf(&sb, `<tr><td><strong>%s</strong></td><td>%s</td></tr>`,
+					html.EscapeString(field.Title), emailHTMLText(field.Value))
+			}
+			sb.WriteString(`</table>`)
+		}
+		if att.ImageURL != "" {
+			fmt.Fprintf(&sb, `<p><img src="%s" alt="%s" style="max-width:100%%"></p>`,
+				html.EscapeString(att.ImageURL), html.EscapeString(att.Title))
+		}
+		if att.Footer != "" {
+			fmt.Fprintf(&sb, `<p style="color:#616061;font-size:12px">%s</p>`, html.EscapeString(att.Footer))
+		}
+		sb.WriteString(`</div>`)
+	}
+
+	sb.WriteString(`</body></html>`)
+	return sb.String()
+}
+
+// emailHTMLText escapes text and preserves line breaks
+func emailHTMLText(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
+
+// emailAddress formats a sender address with an optional display name
+func emailAddress(name, address string) string {
+	if name == "" {
+		return address
+	}
+	return (&mail.Address{Name: name, Address: address}).String()
+}
+
+// emailContentType returns the MIME type for filename, defaulting to application/octet-stream
+func emailContentType(filename string) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(filename)); contentType != "" {
+		return contentType
+	}
+	return "application/octet-stream"
+}
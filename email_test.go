@@ -0,0 +1,59 @@
+package notify
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderEmail(t *testing.T) {
+	email, err := renderEmail("test", &Message{
+		Text:     "Disk <sda> is full\nPlease check",
+		Priority: PriorityHigh,
+		Channel:  "ops@example.com, dev@example.com",
+		Attachments: []Attachment{
+			{Title: "Host", Color: "danger", Fields: []Field{{Title: "Name", Value: "db-1"}}},
+		},
+		Metadata: map[string]interface{}{
+			"cc":          "lead@example.com",
+			"email_files": EmailFile{Filename: "report.json", Content: []byte("{}")},
+		},
+	}, []string{"default@example.com"})
+	if err != nil {
+		t.Fatalf("renderEmail failed: %v", err)
+	}
+
+	if len(email.To) != 2 || email.To[1] != "dev@example.com" {
+		t.Errorf("Expected recipients from channel, got %v", email.To)
+	}
+	if len(email.Cc) != 1 || email.Cc[0] != "lead@example.com" {
+		t.Errorf("Expected cc from metadata, got %v", email.Cc)
+	}
+	if email.Subject != "Disk <sda> is full" {
+		t.Errorf("Expected subject from first line, got %q", email.Subject)
+	}
+	if !strings.Contains(email.Text, "Name: db-1") {
+		t.Errorf("Expected attachment fields in text body, got %q", email.Text)
+	}
+	if !strings.Contains(email.HTML, "Disk &lt;sda&gt; is full<br>Please check") || !strings.Contains(email.HTML, "#e01e5a") {
+		t.Errorf("Unexpected HTML body: %s", email.HTML)
+	}
+	if email.Headers["X-Priority"] != "1" {
+		t.Errorf("Expected high priority header, got %v", email.Headers)
+	}
+	if len(email.Files) != 1 || email.Files[0].ContentType != "application/json" {
+		t.Errorf("Expected detected content type for file, got %+v", email.Files)
+	}
+
+	files := []EmailFile{{Filename: "report.csv", Content: []byte("a,b")}}
+	email, err = renderEmail("test", &Message{Text: "hello", Metadata: map[string]interface{}{"email_files": files}}, []string{"default@example.com"})
+	if err != nil {
+		t.Fatalf("renderEmail failed: %v", err)
+	}
+	if email.Files[0].ContentType == "" || files[0].ContentType != "" {
+		t.Errorf("Expected content type on a copy of the caller's files, got %+v and %+v", email.Files, files)
+	}
+
+	if _, err := renderEmail("test", &Message{Text: "hello"}, nil); err == nil {
+		t.Error("Expected error when there are no recipients")
+	}
+}
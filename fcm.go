@@ -0,0 +1,519 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrFCMTokenUnregistered is returned (wrapped in a NotificationError) when FCM reports
+// that a device registration token is no longer valid and should be removed
+var ErrFCMTokenUnregistered = errors.New("fcm registration token is unregistered")
+
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// FCMNotifier sends push notifications via Firebase Cloud Messaging HTTP v1 API
+type FCMNotifier struct {
+	name        string
+	projectID   string
+	clientEmail string
+	keyID       string
+	privateKey  *rsa.PrivateKey
+	tokenURL    string
+	baseURL     string
+	defaultTo   string
+	client      *http.Client
+
+	// accessToken is the cached OAuth2 access token
+	accessToken string
+	tokenExpiry time.Time
+	mu          sync.Mutex
+}
+
+// FCMConfig holds configuration for Firebase Cloud Messaging notifications
+type FCMConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "fcm")
+	Name string
+
+	// CredentialsJSON is the content of a service account key file
+	CredentialsJSON []byte
+
+	// CredentialsFile is the path to a service account key file (alternative to CredentialsJSON)
+	CredentialsFile string
+
+	// ProjectID overrides the project ID from the service account (optional)
+	ProjectID string
+
+	// DefaultTarget is the default device token, "topic:<name>" or "condition:<expr>" (optional)
+	DefaultTarget string
+
+	// TokenURL overrides the OAuth2 token endpoint (optional, for testing)
+	TokenURL string
+
+	// BaseURL overrides the FCM API base URL (optional, for testing)
+	BaseURL string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type fcmServiceAccount struct {
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+type fcmTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+type fcmErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+		Status  string `json:"status"`
+		Details []struct {
+			ErrorCode string `json:"errorCode"`
+		} `json:"details"`
+	} `json:"error"`
+}
+
+// NewFCMNotifier creates a new Firebase Cloud Messaging notifier
+func NewFCMNotifier(config FCMConfig) (*FCMNotifier, error) {
+	credentials := config.CredentialsJSON
+	if len(credentials) == 0 && config.CredentialsFile != "" {
+		data, err := os.ReadFile(config.CredentialsFile)
+		if err != nil {
+			return nil, &NotificationError{
+				Provider: "fcm",
+				Message:  "failed to read credentials file",
+				Err:      err,
+			}
+		}
+		credentials = data
+	}
+
+	if len(credentials) == 0 {
+		return nil, &NotificationError{
+			Provider: "fcm",
+			Message:  "service account credentials are required",
+		}
+	}
+
+	var account fcmServiceAccount
+	if err := json.Unmarshal(credentials, &account); err != nil {
+		return nil, &NotificationError{
+			Provider: "fcm",
+			Message:  "failed to parse service account credentials",
+			Err:      err,
+		}
+	}
+
+	if account.ClientEmail == "" || account.PrivateKey == "" {
+		return nil, &NotificationError{
+			Provider: "fcm",
+			Message:  "service account credentials must contain client_email and private_key",
+		}
+	}
+
+	privateKey, err := parseRSAPrivateKey([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, &NotificationError{
+			Provider: "fcm",
+			Message:  "failed to parse service account private key",
+			Err:      err,
+		}
+	}
+
+	projectID := config.ProjectID
+	if projectID == "" {
+		projectID = account.ProjectID
+	}
+	if projectID == "" {
+		return nil, &NotificationError{
+			Provider: "fcm",
+			Message:  "project ID is required",
+		}
+	}
+
+	tokenURL := config.TokenURL
+	if tokenURL == "" {
+		tokenURL = account.TokenURI
+	}
+	if tokenURL == "" {
+		tokenURL = "https://oauth2.googleapis.com/token"
+	}
+
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://fcm.googleapis.com"
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "fcm"
+	}
+
+	return &FCMNotifier{
+		name:        name,
+		projectID:   projectID,
+		clientEmail: account.ClientEmail,
+		keyID:       account.PrivateKeyID,
+		privateKey:  privateKey,
+		tokenURL:    tokenURL,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		defaultTo:   config.DefaultTarget,
+		client:      newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (f *FCMNotifier) Name() string {
+	return f.name
+}
+
+// Send sends a notification with the given body to the default target
+func (f *FCMNotifier) Send(ctx context.Context, message string) error {
+	return f.SendWithOptions(ctx, &Message{
+		Text:    message,
+		Channel: f.defaultTo,
+	})
+}
+
+// SendWithOptions sends a push notification.
+// Channel is a device token, "topic:<name>" or "condition:<expr>"; Title and Text map
+// to the notification payload and Metadata is sent as string data.
+func (f *FCMNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if msg.Text == "" && msg.Title == "" {
+		return &NotificationError{
+			Provider: "fcm",
+			Message:  "message text is required",
+		}
+	}
+
+	notification := map[string]interface{}{
+		"body": msg.Text,
+	}
+	if msg.Title != "" {
+		notification["title"] = msg.Title
+	}
+	for _, att := range msg.Attachments {
+		if att.ImageURL != "" {
+			notification["image"] = att.ImageURL
+			break
+		}
+	}
+
+	message := map[string]interface{}{
+		"notification": notification,
+	}
+
+	if len(msg.Metadata) > 0 {
+		data := make(map[string]string, len(msg.Metadata))
+		for key, value := range msg.Metadata {
+			data[key] = fmt.Sprintf("%v", value)
+		}
+		message["data"] = data
+	}
+
+	androidPriority, apnsPriority := "NORMAL", "5"
+	if msg.Priority == PriorityHigh {
+		androidPriority, apnsPriority = "HIGH", "10"
+	}
+	message["android"] = map[string]interface{}{"priority": androidPriority}
+	message["apns"] = map[string]interface{}{
+		"headers": map[string]string{"apns-priority": apnsPriority},
+	}
+
+	if err := f.setTarget(message, msg.Channel); err != nil {
+		return err
+	}
+
+	_, err := f.send(ctx, message)
+	return err
+}
+
+// SendRichMessage sends a raw FCM message.
+// blocks must be a map[string]interface{} holding the v1 "message" object; channel,
+// if set, sets its target (device token, "topic:<name>" or "condition:<expr>").
+func (f *FCMNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	message, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "fcm",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	_, hasToken := message["token"]
+	_, hasTopic := message["topic"]
+	_, hasCondition := message["condition"]
+	if channel != "" || !(hasToken || hasTopic || hasCondition) {
+		if err := f.setTarget(message, channel); err != nil {
+			return err
+		}
+	}
+
+	_, err := f.send(ctx, message)
+	return err
+}
+
+// setTarget sets the token, topic or condition of message from target,
+// falling back to the default target when empty
+func (f *FCMNotifier) setTarget(message map[string]interface{}, target string) error {
+	if target == "" {
+		target = f.defaultTo
+	}
+
+	switch {
+	case target == "":
+		return &NotificationError{
+			Provider: "fcm",
+			Message:  "target is required",
+		}
+	case strings.HasPrefix(target, "topic:"):
+		message["topic"] = strings.TrimPrefix(target, "topic:")
+	case strings.HasPrefix(target, "/topics/"):
+		message["topic"] = strings.TrimPrefix(target, "/topics/")
+	case strings.HasPrefix(target, "condition:"):
+		message["condition"] = strings.TrimPrefix(target, "condition:")
+	default:
+		message["token"] = target
+	}
+
+	return nil
+}
+
+// send posts message and returns the message name assigned by FCM
+func (f *FCMNotifier) send(ctx context.Context, message map[string]interface{}) (string, error) {
+	body, err := json.Marshal(map[string]interface{}{"message": message})
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "fcm",
+			Message:  "failed to marshal request",
+			Err:      err,
+		}
+	}
+
+	status, respBody, err := f.post(ctx, body)
+	if err == nil && status == http.StatusUnauthorized {
+		// The cached token may have been revoked before it expired: retry once with a new one
+		status, respBody, err = f.post(ctx, body)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK {
+		return "", fcmError(status, respBody)
+	}
+
+	var result struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", &NotificationError{
+			Provider: "fcm",
+			Message:  "failed to parse response",
+			Err:      err,
+		}
+	}
+
+	return result.Name, nil
+}
+
+// post sends body to the messages:send endpoint, discarding the access token if it is rejected
+func (f *FCMNotifier) post(ctx context.Context, body []byte) (int, []byte, error) {
+	accessToken, err := f.token(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/v1/projects/%s/messages:send", f.baseURL, url.PathEscape(f.projectID))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, &NotificationError{
+			Provider: "fcm",
+			Message:  "failed to create request",
+			Err:      err,
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+
+	status, respBody, err := doRequest(f.client, "fcm", req)
+	if err == nil && status == http.StatusUnauthorized {
+		f.mu.Lock()
+		// Another send may already have replaced the token
+		if f.accessToken == accessToken {
+			f.accessToken = ""
+		}
+		f.mu.Unlock()
+	}
+
+	return status, respBody, err
+}
+
+// token returns a cached OAuth2 access token, requesting a new one when it expires
+func (f *FCMNotifier) token(ctx context.Context) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.accessToken != "" && time.Now().Before(f.tokenExpiry) {
+		return f.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := signJWT(f.privateKey, f.keyID, map[string]interface{}{
+		"iss":   f.clientEmail,
+		"scope": fcmScope,
+		"aud":   f.tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "fcm",
+			Message:  "failed to sign token request",
+			Err:      err,
+		}
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	var result fcmTokenResponse
+	if err := sendForm(ctx, f.client, "fcm", f.tokenURL, nil, form, &result); err != nil {
+		return "", err
+	}
+
+	if result.AccessToken == "" {
+		return "", &NotificationError{
+			Provider: "fcm",
+			Message:  "token endpoint returned no access token",
+		}
+	}
+
+	expiresIn := time.Duration(result.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = time.Hour
+	}
+
+	// Refresh a minute early to avoid using a token that expires in flight,
+	// but never more than halfway through the token's lifetime
+	margin := time.Minute
+	if margin > expiresIn/2 {
+		margin = expiresIn / 2
+	}
+
+	f.accessToken = result.AccessToken
+	f.tokenExpiry = now.Add(expiresIn - margin)
+
+	return f.accessToken, nil
+}
+
+// fcmError converts an FCM error response into a NotificationError,
+// wrapping ErrFCMTokenUnregistered for stale registration tokens
+func fcmError(status int, body []byte) error {
+	var result fcmErrorResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return &NotificationError{
+			Provider: "fcm",
+			Message:  fmt.Sprintf("API request failed with status %d: %s", status, string(body)),
+		}
+	}
+
+	for _, detail := range result.Error.Details {
+		if detail.ErrorCode == "UNREGISTERED" {
+			return &NotificationError{
+				Provider: "fcm",
+				Message:  result.Error.Message,
+				Err:      ErrFCMTokenUnregistered,
+			}
+		}
+	}
+
+	return &NotificationError{
+		Provider: "fcm",
+		Message:  fmt.Sprintf("API request failed with status %d (%s): %s", status, result.Error.Status, result.Error.Message),
+	}
+}
+
+// parseRSAPrivateKey parses a PEM encoded PKCS#8 or PKCS#1 RSA private key
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key is not an RSA key")
+	}
+
+	return key, nil
+}
+
+// signJWT returns an RS256 signed JWT with the given claims
+func signJWT(key *rsa.PrivateKey, keyID string, claims map[string]interface{}) (string, error) {
+	header := map[string]string{"alg": "RS256", "typ": "JWT"}
+	if keyID != "" {
+		header["kid"] = keyID
+	}
+
+	signingInput, err := jwtSigningInput(header, claims)
+	if err != nil {
+		return "", err
+	}
+
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// jwtSigningInput returns the base64url encoded header and claims joined by a dot
+func jwtSigningInput(header map[string]string, claims map[string]interface{}) (string, error) {
+	headerJSON, err := json.Marshal(header)
+	if err != nil {
+		return "", err
+	}
+
+	claimsJSON, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(claimsJSON), nil
+}
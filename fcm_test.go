@@ -0,0 +1,201 @@
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestFCMCredentials(t *testing.T, tokenURI string) []byte {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	credentials, err := json.Marshal(map[string]string{
+		"type":           "service_account",
+		"project_id":     "test-project",
+		"private_key_id": "kid",
+		"private_key":    string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		"client_email":   "notify@test-project.iam.gserviceaccount.com",
+		"token_uri":      tokenURI,
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal credentials: %v", err)
+	}
+
+	return credentials
+}
+
+func TestNewFCMNotifier(t *testing.T) {
+	_, err := NewFCMNotifier(FCMConfig{})
+	if err == nil {
+		t.Error("Expected error when credentials are missing")
+	}
+
+	notifier, err := NewFCMNotifier(FCMConfig{CredentialsJSON: newTestFCMCredentials(t, "")})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "fcm" {
+		t.Errorf("Expected name 'fcm', got '%s'", notifier.Name())
+	}
+}
+
+func TestFCMSendWithOptions(t *testing.T) {
+	tokenRequests := 0
+	var message map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokenRequests++
+			if err := r.ParseForm(); err != nil {
+				t.Errorf("Failed to parse form: %v", err)
+			}
+			if r.PostForm.Get("grant_type") != "urn:ietf:params:oauth:grant-type:jwt-bearer" {
+				t.Errorf("Unexpected grant type: %s", r.PostForm.Get("grant_type"))
+			}
+			if len(strings.Split(r.PostForm.Get("assertion"), ".")) != 3 {
+				t.Errorf("Expected JWT assertion, got %s", r.PostForm.Get("assertion"))
+			}
+			_, _ = w.Write([]byte(`{"access_token":"access","expires_in":3600,"token_type":"Bearer"}`))
+		case "/v1/projects/test-project/messages:send":
+			if r.Header.Get("Authorization") != "Bearer access" {
+				t.Errorf("Unexpected Authorization header: %s", r.Header.Get("Authorization"))
+			}
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Failed to decode body: %v", err)
+			}
+			message = body["message"].(map[string]interface{})
+
+			if message["token"] == "stale-token" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Requested entity was not found.","status":"NOT_FOUND",` +
+					`"details":[{"@type":"type.googleapis.com/google.firebase.fcm.v1.FcmError","errorCode":"UNREGISTERED"}]}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"name":"projects/test-project/messages/1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewFCMNotifier(FCMConfig{
+		CredentialsJSON: newTestFCMCredentials(t, server.URL+"/token"),
+		BaseURL:         server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	ctx := context.Background()
+	err = notifier.SendWithOptions(ctx, &Message{
+		Title:    "Order shipped",
+		Text:     "Your order is on its way",
+		Channel:  "topic:orders",
+		Metadata: map[string]interface{}{"order_id": 42},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if message["topic"] != "orders" {
+		t.Errorf("Expected topic 'orders', got '%v'", message["topic"])
+	}
+
+	data := message["data"].(map[string]interface{})
+	if data["order_id"] != "42" {
+		t.Errorf("Expected data order_id '42', got '%v'", data["order_id"])
+	}
+
+	err = notifier.SendWithOptions(ctx, &Message{Text: "hello", Channel: "stale-token"})
+	if !errors.Is(err, ErrFCMTokenUnregistered) {
+		t.Errorf("Expected ErrFCMTokenUnregistered, got %v", err)
+	}
+
+	if tokenRequests != 1 {
+		t.Errorf("Expected access token to be cached, got %d token requests", tokenRequests)
+	}
+}
+
+func TestFCMTokenRefresh(t *testing.T) {
+	tokenRequests := 0
+	valid := ""
+	reject := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/token":
+			tokenRequests++
+			valid = fmt.Sprintf("access-%d", tokenRequests)
+			_, _ = fmt.Fprintf(w, `{"access_token":%q,"expires_in":30,"token_type":"Bearer"}`, valid)
+		default:
+			if reject || r.Header.Get("Authorization") != "Bearer "+valid {
+				w.WriteHeader(http.StatusUnauthorized)
+				_, _ = w.Write([]byte(`{"error":{"code":401,"message":"Request had invalid authentication credentials.","status":"UNAUTHENTICATED"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"name":"projects/test-project/messages/1"}`))
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewFCMNotifier(FCMConfig{
+		CredentialsJSON: newTestFCMCredentials(t, server.URL+"/token"),
+		BaseURL:         server.URL,
+		DefaultTarget:   "topic:orders",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	// A token shorter-lived than the refresh margin must still be cached
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := notifier.Send(ctx, "hello"); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+	if tokenRequests != 1 {
+		t.Errorf("Expected short-lived token to be cached, got %d token requests", tokenRequests)
+	}
+
+	// A revoked token is replaced and the send retried
+	valid = "revoked"
+	if err := notifier.Send(ctx, "hello"); err != nil {
+		t.Fatalf("Send with revoked token failed: %v", err)
+	}
+	if tokenRequests != 2 {
+		t.Errorf("Expected a new token after 401, got %d token requests", tokenRequests)
+	}
+
+	// Credentials that are rejected outright are retried only once
+	reject = true
+	err = notifier.Send(ctx, "hello")
+	if err == nil || !strings.Contains(err.Error(), "401") {
+		t.Errorf("Expected 401 error, got %v", err)
+	}
+	if tokenRequests != 3 {
+		t.Errorf("Expected one retry with a new token, got %d token requests", tokenRequests)
+	}
+}
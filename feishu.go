@@ -0,0 +1,244 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FeishuNotifier sends notifications to Feishu/Lark group custom bots
+type FeishuNotifier struct {
+	name       string
+	webhookURL string
+	secret     string
+	client     *http.Client
+}
+
+// FeishuConfig holds configuration for Feishu/Lark notifications
+type FeishuConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "feishu")
+	Name string
+
+	// WebhookURL is the custom bot webhook URL (open.feishu.cn or open.larksuite.com)
+	WebhookURL string
+
+	// Secret is the signing secret when signature verification is enabled (optional)
+	Secret string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type feishuResponse struct {
+	Code int    `json:"code"`
+	Msg  string `json:"msg"`
+}
+
+// NewFeishuNotifier creates a new Feishu/Lark notifier
+func NewFeishuNotifier(config FeishuConfig) (*FeishuNotifier, error) {
+	if config.WebhookURL == "" {
+		return nil, &NotificationError{
+			Provider: "feishu",
+			Message:  "webhook URL is required",
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "feishu"
+	}
+
+	return &FeishuNotifier{
+		name:       name,
+		webhookURL: config.WebhookURL,
+		secret:     config.Secret,
+		client:     newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (f *FeishuNotifier) Name() string {
+	return f.name
+}
+
+// Send sends a simple text message
+func (f *FeishuNotifier) Send(ctx context.Context, message string) error {
+	return f.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Messages with a title or attachments are rendered as an interactive card.
+// Metadata keys "mention_users" (open IDs) and "mention_all" @mention group members.
+func (f *FeishuNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if msg.Text == "" {
+		return &NotificationError{
+			Provider: "feishu",
+			Message:  "message text is required",
+		}
+	}
+
+	users := metadataStrings(msg, "mention_users")
+	mentionAll, _ := msg.Metadata["mention_all"].(bool)
+
+	if msg.Title == "" && len(msg.Attachments) == 0 {
+		var sb strings.Builder
+		sb.WriteString(msg.Text)
+		for _, user := range users {
+			fmt.Fprintf(&sb, ` <at user_id="%s"></at>`, user)
+		}
+		if mentionAll {
+			sb.WriteString(` <at user_id="all"></at>`)
+		}
+
+		return f.post(ctx, msg.Channel, map[string]interface{}{
+			"msg_type": "text",
+			"content":  map[string]string{"text": sb.String()},
+		})
+	}
+
+	return f.post(ctx, msg.Channel, map[string]interface{}{
+		"msg_type": "interactive",
+		"card":     f.buildCard(msg, users, mentionAll),
+	})
+}
+
+// SendRichMessage sends a raw interactive card.
+// blocks must be a map[string]interface{} holding the card JSON; channel, if set,
+// overrides the webhook URL.
+func (f *FeishuNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	card, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "feishu",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	return f.post(ctx, channel, map[string]interface{}{
+		"msg_type": "interactive",
+		"card":     card,
+	})
+}
+
+// buildCard renders the message title, text, attachments and mentions as a card
+func (f *FeishuNotifier) buildCard(msg *Message, users []string, mentionAll bool) map[string]interface{} {
+	text := msg.Text
+	for _, user := range users {
+		text += fmt.Sprintf(" <at id=%s></at>", user)
+	}
+	if mentionAll {
+		text += " <at id=all></at>"
+	}
+
+	elements := []interface{}{
+		feishuMarkdown(text),
+	}
+
+	for _, att := range msg.Attachments {
+		elements = append(elements, map[string]interface{}{"tag": "hr"})
+
+		if att.Title != "" || att.Text != "" {
+			content := att.Text
+			if att.Title != "" {
+				content = "**" + att.Title + "**\n" + att.Text
+			}
+			elements = append(elements, feishuMarkdown(strings.TrimSpace(content)))
+		}
+
+		if len(att.Fields) > 0 {
+			fields := make([]interface{}, len(att.Fields))
+			for i, field := range att.Fields {
+				fields[i] = map[string]interface{}{
+					"is_short": field.Short,
+					"text": map[string]string{
+						"tag":     "lark_md",
+						"content": "**" + field.Title + "**\n" + field.Value,
+					},
+				}
+			}
+			elements = append(elements, map[string]interface{}{"tag": "div", "fields": fields})
+		}
+
+		if att.Footer != "" {
+			elements = append(elements, map[string]interface{}{
+				"tag":      "note",
+				"elements": []interface{}{map[string]string{"tag": "plain_text", "content": att.Footer}},
+			})
+		}
+	}
+
+	card := map[string]interface{}{
+		"config":   map[string]bool{"wide_screen_mode": true},
+		"elements": elements,
+	}
+
+	if msg.Title != "" {
+		card["header"] = map[string]interface{}{
+			"title":    map[string]string{"tag": "plain_text", "content": msg.Title},
+			"template": feishuTemplate(msg.Priority),
+		}
+	}
+
+	return card
+}
+
+// post adds the signature (if a secret is configured) and sends payload
+func (f *FeishuNotifier) post(ctx context.Context, webhookURL string, payload map[string]interface{}) error {
+	if webhookURL == "" {
+		webhookURL = f.webhookURL
+	}
+
+	if f.secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		payload["timestamp"] = timestamp
+		payload["sign"] = feishuSign(timestamp, f.secret)
+	}
+
+	var result feishuResponse
+	if err := sendJSON(ctx, f.client, "feishu", http.MethodPost, webhookURL, nil, payload, &result); err != nil {
+		return err
+	}
+
+	if result.Code != 0 {
+		return &NotificationError{
+			Provider: "feishu",
+			Message:  fmt.Sprintf("API returned error %d: %s", result.Code, result.Msg),
+		}
+	}
+
+	return nil
+}
+
+// feishuSign computes the bot signature: base64(HMAC-SHA256 keyed with timestamp + "\n" + secret over an empty message)
+func feishuSign(timestamp, secret string) string {
+	mac := hmac.New(sha256.New, []byte(timestamp+"\n"+secret))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// feishuMarkdown returns a div element with lark_md content
+func feishuMarkdown(content string) map[string]interface{} {
+	return map[string]interface{}{
+		"tag":  "div",
+		"text": map[string]string{"tag": "lark_md", "content": content},
+	}
+}
+
+// feishuTemplate maps a message priority to a card header color
+func feishuTemplate(priority string) string {
+	switch priority {
+	case PriorityHigh:
+		return "red"
+	case PriorityLow:
+		return "grey"
+	default:
+		return "blue"
+	}
+}
@@ -0,0 +1,73 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFeishuSign(t *testing.T) {
+	// base64(HMAC-SHA256(key "1700000000\nsecret", "")), computed independently
+	want := "fiWS2+gh28DOydAv7hzONH/mDn9+b1Y4Y5ivXWXy8vA="
+	if got := feishuSign("1700000000", "secret"); got != want {
+		t.Errorf("Expected signature %s, got %s", want, got)
+	}
+}
+
+func TestFeishuSignedCard(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"code":0,"msg":"success"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewFeishuNotifier(FeishuConfig{WebhookURL: server.URL, Secret: "secret"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "feishu" {
+		t.Errorf("Expected name 'feishu', got '%s'", notifier.Name())
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Title:    "CPU high",
+		Text:     "CPU usage above 90%",
+		Priority: PriorityHigh,
+		Attachments: []Attachment{
+			{Title: "Host", Fields: []Field{{Title: "Name", Value: "web-1", Short: true}}},
+		},
+		Metadata: map[string]interface{}{"mention_users": []string{"ou_123"}},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	// feishuSign itself is checked against a known signature in TestFeishuSign
+	timestamp, _ := body["timestamp"].(string)
+	if body["sign"] != feishuSign(timestamp, "secret") {
+		t.Errorf("Invalid signature for timestamp %s", timestamp)
+	}
+
+	if body["msg_type"] != "interactive" {
+		t.Fatalf("Expected interactive message, got %v", body["msg_type"])
+	}
+
+	card := body["card"].(map[string]interface{})
+	header := card["header"].(map[string]interface{})
+	if header["template"] != "red" {
+		t.Errorf("Expected red header for high priority, got %v", header["template"])
+	}
+
+	elements := card["elements"].([]interface{})
+	first := elements[0].(map[string]interface{})["text"].(map[string]interface{})
+	if !strings.Contains(first["content"].(string), "<at id=ou_123></at>") {
+		t.Errorf("Expected mention in card text, got %v", first["content"])
+	}
+}
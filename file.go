@@ -0,0 +1,222 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileNotifier appends notifications to a JSON Lines file, for local development and testing
+type FileNotifier struct {
+	name       string
+	path       string
+	maxSize    int64
+	maxBackups int
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// FileConfig holds configuration for file notifications
+type FileConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "file").
+	// Set it to a production provider name (e.g., "slack") to swap providers in development.
+	Name string
+
+	// Path is the JSON Lines file notifications are appended to
+	Path string
+
+	// MaxSize is the size in bytes at which the file is rotated (optional, 0 disables rotation)
+	MaxSize int64
+
+	// MaxBackups is the number of rotated files to keep (optional, defaults to 3)
+	MaxBackups int
+}
+
+// FileRecord is a single line written by the file notifier
+type FileRecord struct {
+	Time     time.Time   `json:"time"`
+	Notifier string      `json:"notifier"`
+	Message  *Message    `json:"message,omitempty"`
+	Channel  string      `json:"channel,omitempty"`
+	Blocks   interface{} `json:"blocks,omitempty"`
+}
+
+// NewFileNotifier creates a new file notifier
+func NewFileNotifier(config FileConfig) (*FileNotifier, error) {
+	if config.Path == "" {
+		return nil, &NotificationError{
+			Provider: "file",
+			Message:  "path is required",
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "file"
+	}
+
+	maxBackups := config.MaxBackups
+	if maxBackups <= 0 {
+		maxBackups = 3
+	}
+
+	return &FileNotifier{
+		name:       name,
+		path:       config.Path,
+		maxSize:    config.MaxSize,
+		maxBackups: maxBackups,
+	}, nil
+}
+
+// Name returns the name of the provider
+func (f *FileNotifier) Name() string {
+	return f.name
+}
+
+// Send writes a simple text message
+func (f *FileNotifier) Send(ctx context.Context, message string) error {
+	return f.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions writes the full message including attachments and metadata
+func (f *FileNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	return f.write(ctx, FileRecord{
+		Message: msg,
+		Channel: msg.Channel,
+	})
+}
+
+// SendRichMessage writes the rich message blocks as they would be sent
+func (f *FileNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	return f.write(ctx, FileRecord{
+		Channel: channel,
+		Blocks:  blocks,
+	})
+}
+
+// Close closes the underlying file
+func (f *FileNotifier) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// write encodes record as a single line and appends it, rotating the file if needed
+func (f *FileNotifier) write(ctx context.Context, record FileRecord) error {
+	if err := ctx.Err(); err != nil {
+		return &NotificationError{
+			Provider: f.name,
+			Message:  "context done",
+			Err:      err,
+		}
+	}
+
+	record.Time = time.Now().UTC()
+	record.Notifier = f.name
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return &NotificationError{
+			Provider: f.name,
+			Message:  "failed to marshal message",
+			Err:      err,
+		}
+	}
+	line = append(line, '\n')
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.open(); err != nil {
+		return err
+	}
+
+	if f.maxSize > 0 && f.size > 0 && f.size+int64(len(line)) > f.maxSize {
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := f.file.Write(line)
+	f.size += int64(n)
+	if err != nil {
+		return &NotificationError{
+			Provider: f.name,
+			Message:  "failed to write message",
+			Err:      err,
+		}
+	}
+
+	return nil
+}
+
+// open opens the file for appending if it isn't open yet
+func (f *FileNotifier) open() error {
+	if f.file != nil {
+		return nil
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return &NotificationError{
+			Provider: f.name,
+			Message:  "failed to open file",
+			Err:      err,
+		}
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return &NotificationError{
+			Provider: f.name,
+			Message:  "failed to stat file",
+			Err:      err,
+		}
+	}
+
+	f.file = file
+	f.size = info.Size()
+	return nil
+}
+
+// rotate shifts path.N to path.N+1 (dropping the oldest), moves path to path.1 and reopens path
+func (f *FileNotifier) rotate() error {
+	if err := f.file.Close(); err != nil {
+		return &NotificationError{
+			Provider: f.name,
+			Message:  "failed to close file",
+			Err:      err,
+		}
+	}
+	f.file = nil
+
+	_ = os.Remove(fmt.Sprintf("%s.%d", f.path, f.maxBackups))
+	for i := f.maxBackups - 1; i >= 1; i-- {
+		_ = os.Rename(fmt.Sprintf("%s.%d", f.path, i), fmt.Sprintf("%s.%d", f.path, i+1))
+	}
+
+	if err := os.Rename(f.path, f.path+".1"); err != nil {
+		return &NotificationError{
+			Provider: f.name,
+			Message:  "failed to rotate file",
+			Err:      err,
+		}
+	}
+
+	return f.open()
+}
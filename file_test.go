@@ -0,0 +1,125 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNewFileNotifier(t *testing.T) {
+	_, err := NewFileNotifier(FileConfig{})
+	if err == nil {
+		t.Error("Expected error when path is missing")
+	}
+
+	notifier, err := NewFileNotifier(FileConfig{Path: "notifications.jsonl", Name: "slack"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "slack" {
+		t.Errorf("Expected name 'slack', got '%s'", notifier.Name())
+	}
+}
+
+func TestFileNotifierWritesFullMessage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.jsonl")
+
+	notifier, err := NewFileNotifier(FileConfig{Path: path})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	msg := &Message{
+		Title:    "Deploy",
+		Text:     "v1.2.3 deployed",
+		Priority: PriorityHigh,
+		Channel:  "#ops",
+		Attachments: []Attachment{
+			{Title: "Details", Color: "good", Fields: []Field{{Title: "Env", Value: "prod", Short: true}}},
+		},
+		Metadata: map[string]interface{}{"thread_key": "deploys"},
+	}
+	if err := notifier.SendWithOptions(context.Background(), msg); err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+	if err := notifier.SendRichMessage(context.Background(), "#ops", []map[string]string{{"type": "divider"}}); err != nil {
+		t.Fatalf("SendRichMessage failed: %v", err)
+	}
+
+	records := readFileRecords(t, path)
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	got := records[0].Message
+	if got == nil || got.Title != "Deploy" || got.Priority != PriorityHigh || got.Channel != "#ops" {
+		t.Fatalf("Unexpected message: %+v", got)
+	}
+	if len(got.Attachments) != 1 || got.Attachments[0].Fields[0].Value != "prod" || !got.Attachments[0].Fields[0].Short {
+		t.Errorf("Attachments not preserved: %+v", got.Attachments)
+	}
+	if got.Metadata["thread_key"] != "deploys" {
+		t.Errorf("Metadata not preserved: %v", got.Metadata)
+	}
+
+	if records[1].Message != nil || records[1].Blocks == nil || records[1].Channel != "#ops" {
+		t.Errorf("Unexpected rich record: %+v", records[1])
+	}
+}
+
+func TestFileNotifierRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.jsonl")
+
+	notifier, err := NewFileNotifier(FileConfig{Path: path, MaxSize: 200, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	for i := 0; i < 10; i++ {
+		if err := notifier.Send(context.Background(), "a message long enough to fill the file quickly"); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+
+	for _, name := range []string{path, path + ".1", path + ".2"} {
+		info, err := os.Stat(name)
+		if err != nil {
+			t.Fatalf("Expected %s to exist: %v", name, err)
+		}
+		if info.Size() > 200 {
+			t.Errorf("Expected %s to be at most 200 bytes, got %d", name, info.Size())
+		}
+	}
+
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("Expected only 2 backups to be kept")
+	}
+}
+
+func readFileRecords(t *testing.T, path string) []FileRecord {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	defer file.Close()
+
+	var records []FileRecord
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record FileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+
+	return records
+}
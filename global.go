@@ -39,6 +39,106 @@ func Setup(configs ...interface{}) error {
 			notifier, err = NewTelegramNotifier(*cfg)
 		case TelegramConfig:
 			notifier, err = NewTelegramNotifier(cfg)
+		case *PagerDutyConfig:
+			notifier, err = NewPagerDutyNotifier(*cfg)
+		case PagerDutyConfig:
+			notifier, err = NewPagerDutyNotifier(cfg)
+		case *OpsgenieConfig:
+			notifier, err = NewOpsgenieNotifier(*cfg)
+		case OpsgenieConfig:
+			notifier, err = NewOpsgenieNotifier(cfg)
+		case *GoogleChatConfig:
+			notifier, err = NewGoogleChatNotifier(*cfg)
+		case GoogleChatConfig:
+			notifier, err = NewGoogleChatNotifier(cfg)
+		case *MattermostConfig:
+			notifier, err = NewMattermostNotifier(*cfg)
+		case MattermostConfig:
+			notifier, err = NewMattermostNotifier(cfg)
+		case *RocketChatConfig:
+			notifier, err = NewRocketChatNotifier(*cfg)
+		case RocketChatConfig:
+			notifier, err = NewRocketChatNotifier(cfg)
+		case *MatrixConfig:
+			notifier, err = NewMatrixNotifier(*cfg)
+		case MatrixConfig:
+			notifier, err = NewMatrixNotifier(cfg)
+		case *NtfyConfig:
+			notifier, err = NewNtfyNotifier(*cfg)
+		case NtfyConfig:
+			notifier, err = NewNtfyNotifier(cfg)
+		case *GotifyConfig:
+			notifier, err = NewGotifyNotifier(*cfg)
+		case GotifyConfig:
+			notifier, err = NewGotifyNotifier(cfg)
+		case *PushoverConfig:
+			notifier, err = NewPushoverNotifier(*cfg)
+		case PushoverConfig:
+			notifier, err = NewPushoverNotifier(cfg)
+		case *FCMConfig:
+			notifier, err = NewFCMNotifier(*cfg)
+		case FCMConfig:
+			notifier, err = NewFCMNotifier(cfg)
+		case *APNSConfig:
+			notifier, err = NewAPNSNotifier(*cfg)
+		case APNSConfig:
+			notifier, err = NewAPNSNotifier(cfg)
+		case *SNSConfig:
+			notifier, err = NewSNSNotifier(*cfg)
+		case SNSConfig:
+			notifier, err = NewSNSNotifier(cfg)
+		case *DingTalkConfig:
+			notifier, err = NewDingTalkNotifier(*cfg)
+		case DingTalkConfig:
+			notifier, err = NewDingTalkNotifier(cfg)
+		case *FeishuConfig:
+			notifier, err = NewFeishuNotifier(*cfg)
+		case FeishuConfig:
+			notifier, err = NewFeishuNotifier(cfg)
+		case *WeComConfig:
+			notifier, err = NewWeComNotifier(*cfg)
+		case WeComConfig:
+			notifier, err = NewWeComNotifier(cfg)
+		case *ConsoleConfig:
+			notifier, err = NewConsoleNotifier(*cfg)
+		case ConsoleConfig:
+			notifier, err = NewConsoleNotifier(cfg)
+		case *FileConfig:
+			notifier, err = NewFileNotifier(*cfg)
+		case FileConfig:
+			notifier, err = NewFileNotifier(cfg)
+		case *SyslogConfig:
+			notifier, err = NewSyslogNotifier(*cfg)
+		case SyslogConfig:
+			notifier, err = NewSyslogNotifier(cfg)
+		case *JournaldConfig:
+			notifier, err = NewJournaldNotifier(*cfg)
+		case JournaldConfig:
+			notifier, err = NewJournaldNotifier(cfg)
+		case *SignalConfig:
+			notifier, err = NewSignalNotifier(*cfg)
+		case SignalConfig:
+			notifier, err = NewSignalNotifier(cfg)
+		case *XMPPConfig:
+			notifier, err = NewXMPPNotifier(*cfg)
+		case XMPPConfig:
+			notifier, err = NewXMPPNotifier(cfg)
+		case *IRCConfig:
+			notifier, err = NewIRCNotifier(*cfg)
+		case IRCConfig:
+			notifier, err = NewIRCNotifier(cfg)
+		case *SendGridConfig:
+			notifier, err = NewSendGridNotifier(*cfg)
+		case SendGridConfig:
+			notifier, err = NewSendGridNotifier(cfg)
+		case *MailgunConfig:
+			notifier, err = NewMailgunNotifier(*cfg)
+		case MailgunConfig:
+			notifier, err = NewMailgunNotifier(cfg)
+		case *SESConfig:
+			notifier, err = NewSESNotifier(*cfg)
+		case SESConfig:
+			notifier, err = NewSESNotifier(cfg)
 		case Notifier:
 			// Allow custom notifiers to be passed directly
 			notifier = cfg
@@ -2,10 +2,10 @@ module github.com/Doraverse-Workspace/notify
 
 go 1.21
 
-require github.com/slack-go/slack v0.12.3
-
 require (
-	github.com/fsnotify/fsnotify v1.9.0 // indirect
-	github.com/gorilla/websocket v1.5.0 // indirect
-	golang.org/x/sys v0.13.0 // indirect
+	github.com/fsnotify/fsnotify v1.9.0
+	github.com/gorilla/websocket v1.5.0
+	github.com/slack-go/slack v0.12.3
 )
+
+require golang.org/x/sys v0.13.0 // indirect
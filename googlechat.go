@@ -0,0 +1,294 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// GoogleChatNotifier sends notifications to Google Chat spaces via incoming webhooks
+type GoogleChatNotifier struct {
+	name       string
+	webhookURL string
+	threadKey  string
+	client     *http.Client
+}
+
+// GoogleChatConfig holds configuration for Google Chat notifications
+type GoogleChatConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "googlechat")
+	Name string
+
+	// WebhookURL is the incoming webhook URL of the space (including key and token)
+	WebhookURL string
+
+	// ThreadKey is the default thread key to reply in (optional)
+	ThreadKey string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type googleChatMessage struct {
+	Text    string             `json:"text,omitempty"`
+	CardsV2 []googleChatCardV2 `json:"cardsV2,omitempty"`
+	Thread  *googleChatThread  `json:"thread,omitempty"`
+}
+
+type googleChatThread struct {
+	ThreadKey string `json:"threadKey"`
+}
+
+type googleChatCardV2 struct {
+	CardID string         `json:"cardId"`
+	Card   googleChatCard `json:"card"`
+}
+
+type googleChatCard struct {
+	Header   *googleChatCardHeader `json:"header,omitempty"`
+	Sections []googleChatSection   `json:"sections,omitempty"`
+}
+
+type googleChatCardHeader struct {
+	Title    string `json:"title"`
+	Subtitle string `json:"subtitle,omitempty"`
+}
+
+type googleChatSection struct {
+	Header      string             `json:"header,omitempty"`
+	Collapsible bool               `json:"collapsible,omitempty"`
+	Widgets     []googleChatWidget `json:"widgets"`
+}
+
+type googleChatWidget struct {
+	TextParagraph *googleChatTextParagraph `json:"textParagraph,omitempty"`
+	DecoratedText *googleChatDecoratedText `json:"decoratedText,omitempty"`
+	Image         *googleChatImage         `json:"image,omitempty"`
+}
+
+type googleChatTextParagraph struct {
+	Text string `json:"text"`
+}
+
+type googleChatDecoratedText struct {
+	TopLabel string `json:"topLabel,omitempty"`
+	Text     string `json:"text"`
+	WrapText bool   `json:"wrapText,omitempty"`
+}
+
+type googleChatImage struct {
+	ImageURL string `json:"imageUrl"`
+	AltText  string `json:"altText,omitempty"`
+}
+
+// NewGoogleChatNotifier creates a new Google Chat notifier
+func NewGoogleChatNotifier(config GoogleChatConfig) (*GoogleChatNotifier, error) {
+	if config.WebhookURL == "" {
+		return nil, &NotificationError{
+			Provider: "googlechat",
+			Message:  "webhook URL is required",
+		}
+	}
+
+	if _, err := url.Parse(config.WebhookURL); err != nil {
+		return nil, &NotificationError{
+			Provider: "googlechat",
+			Message:  "invalid webhook URL",
+			Err:      err,
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "googlechat"
+	}
+
+	return &GoogleChatNotifier{
+		name:       name,
+		webhookURL: config.WebhookURL,
+		threadKey:  config.ThreadKey,
+		client:     newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (g *GoogleChatNotifier) Name() string {
+	return g.name
+}
+
+// Send sends a simple text message
+func (g *GoogleChatNotifier) Send(ctx context.Context, message string) error {
+	return g.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Messages with a title or attachments are rendered as a cardsV2 card; the thread
+// key is taken from Metadata["thread_key"] and falls back to the configured one.
+func (g *GoogleChatNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if msg.Text == "" && msg.Title == "" && len(msg.Attachments) == 0 {
+		return &NotificationError{
+			Provider: "googlechat",
+			Message:  "message text is required",
+		}
+	}
+
+	payload := &googleChatMessage{}
+	if msg.Title == "" && len(msg.Attachments) == 0 {
+		payload.Text = msg.Text
+	} else {
+		payload.CardsV2 = []googleChatCardV2{g.buildCard(msg)}
+	}
+
+	threadKey := metadataString(msg, "thread_key")
+	if threadKey == "" {
+		threadKey = g.threadKey
+	}
+
+	return g.post(ctx, msg.Channel, threadKey, payload)
+}
+
+// SendRichMessage sends raw card JSON.
+// blocks may be a full message body (with "text" and/or "cardsV2"), a single cardsV2
+// entry, or a list of cardsV2 entries, given as json.RawMessage, []byte, string,
+// map[string]interface{} or []interface{}. channel, if set, overrides the webhook URL.
+func (g *GoogleChatNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	var raw interface{}
+	switch v := blocks.(type) {
+	case json.RawMessage:
+		if err := json.Unmarshal(v, &raw); err != nil {
+			return &NotificationError{Provider: "googlechat", Message: "invalid card JSON", Err: err}
+		}
+	case []byte:
+		if err := json.Unmarshal(v, &raw); err != nil {
+			return &NotificationError{Provider: "googlechat", Message: "invalid card JSON", Err: err}
+		}
+	case string:
+		if err := json.Unmarshal([]byte(v), &raw); err != nil {
+			return &NotificationError{Provider: "googlechat", Message: "invalid card JSON", Err: err}
+		}
+	default:
+		raw = blocks
+	}
+
+	var payload map[string]interface{}
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		if _, ok := v["card"]; ok {
+			payload = map[string]interface{}{"cardsV2": []interface{}{v}}
+		} else {
+			payload = v
+		}
+	case []interface{}:
+		payload = map[string]interface{}{"cardsV2": v}
+	default:
+		return &NotificationError{
+			Provider: "googlechat",
+			Message:  fmt.Sprintf("unsupported blocks type: %T", blocks),
+		}
+	}
+
+	threadKey := g.threadKey
+	if thread, ok := payload["thread"].(map[string]interface{}); ok {
+		if key, ok := thread["threadKey"].(string); ok {
+			threadKey = key
+		}
+	} else if threadKey != "" {
+		payload["thread"] = googleChatThread{ThreadKey: threadKey}
+	}
+
+	return g.post(ctx, channel, threadKey, payload)
+}
+
+// buildCard renders the message title, text and attachments into a cardsV2 card
+func (g *GoogleChatNotifier) buildCard(msg *Message) googleChatCardV2 {
+	card := googleChatCard{}
+
+	if msg.Title != "" {
+		card.Header = &googleChatCardHeader{Title: msg.Title}
+		if msg.Priority == PriorityHigh {
+			card.Header.Subtitle = "High priority"
+		}
+	}
+
+	if msg.Text != "" {
+		card.Sections = append(card.Sections, googleChatSection{
+			Widgets: []googleChatWidget{
+				{TextParagraph: &googleChatTextParagraph{Text: msg.Text}},
+			},
+		})
+	}
+
+	for _, att := range msg.Attachments {
+		section := googleChatSection{Header: att.Title}
+
+		if att.Text != "" {
+			section.Widgets = append(section.Widgets, googleChatWidget{
+				TextParagraph: &googleChatTextParagraph{Text: att.Text},
+			})
+		}
+
+		for _, field := range att.Fields {
+			section.Widgets = append(section.Widgets, googleChatWidget{
+				DecoratedText: &googleChatDecoratedText{
+					TopLabel: field.Title,
+					Text:     field.Value,
+					WrapText: !field.Short,
+				},
+			})
+		}
+
+		if att.ImageURL != "" {
+			section.Widgets = append(section.Widgets, googleChatWidget{
+				Image: &googleChatImage{ImageURL: att.ImageURL, AltText: att.Title},
+			})
+		}
+
+		if att.Footer != "" {
+			section.Widgets = append(section.Widgets, googleChatWidget{
+				TextParagraph: &googleChatTextParagraph{Text: fmt.Sprintf("<font color=\"#80868b\">%s</font>", att.Footer)},
+			})
+		}
+
+		// Google Chat rejects sections without widgets
+		if len(section.Widgets) > 0 {
+			card.Sections = append(card.Sections, section)
+		}
+	}
+
+	return googleChatCardV2{
+		CardID: "notify",
+		Card:   card,
+	}
+}
+
+// post sends a message body to the webhook, optionally replying in a thread
+func (g *GoogleChatNotifier) post(ctx context.Context, webhookURL, threadKey string, payload interface{}) error {
+	if webhookURL == "" {
+		webhookURL = g.webhookURL
+	}
+
+	if threadKey != "" {
+		if msg, ok := payload.(*googleChatMessage); ok {
+			msg.Thread = &googleChatThread{ThreadKey: threadKey}
+		}
+
+		u, err := url.Parse(webhookURL)
+		if err != nil {
+			return &NotificationError{
+				Provider: "googlechat",
+				Message:  "invalid webhook URL",
+				Err:      err,
+			}
+		}
+		query := u.Query()
+		query.Set("messageReplyOption", "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD")
+		u.RawQuery = query.Encode()
+		webhookURL = u.String()
+	}
+
+	return sendJSON(ctx, g.client, "googlechat", http.MethodPost, webhookURL, nil, payload, nil)
+}
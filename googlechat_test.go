@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewGoogleChatNotifier(t *testing.T) {
+	_, err := NewGoogleChatNotifier(GoogleChatConfig{})
+	if err == nil {
+		t.Error("Expected error when webhook URL is missing")
+	}
+
+	notifier, err := NewGoogleChatNotifier(GoogleChatConfig{WebhookURL: "https://chat.googleapis.com/v1/spaces/x/messages"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "googlechat" {
+		t.Errorf("Expected name 'googlechat', got '%s'", notifier.Name())
+	}
+}
+
+func TestGoogleChatSendCard(t *testing.T) {
+	var body map[string]interface{}
+	var replyOption string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		replyOption = r.URL.Query().Get("messageReplyOption")
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"name":"spaces/x/messages/y"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewGoogleChatNotifier(GoogleChatConfig{WebhookURL: server.URL + "?key=k&token=t"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Title: "Deployment",
+		Text:  "v1.2.3 is live",
+		Attachments: []Attachment{
+			{Title: "Details", Fields: []Field{{Title: "Env", Value: "prod", Short: true}}},
+		},
+		Metadata: map[string]interface{}{"thread_key": "deploy-123"},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if replyOption != "REPLY_MESSAGE_FALLBACK_TO_NEW_THREAD" {
+		t.Errorf("Expected reply option to be set, got '%s'", replyOption)
+	}
+
+	thread := body["thread"].(map[string]interface{})
+	if thread["threadKey"] != "deploy-123" {
+		t.Errorf("Expected thread key 'deploy-123', got '%v'", thread["threadKey"])
+	}
+
+	cards := body["cardsV2"].([]interface{})
+	card := cards[0].(map[string]interface{})["card"].(map[string]interface{})
+	header := card["header"].(map[string]interface{})
+	if header["title"] != "Deployment" {
+		t.Errorf("Expected header title 'Deployment', got '%v'", header["title"])
+	}
+
+	sections := card["sections"].([]interface{})
+	if len(sections) != 2 {
+		t.Fatalf("Expected 2 sections, got %d", len(sections))
+	}
+}
+
+func TestGoogleChatSendTitleOnlyCard(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`{"name":"spaces/x/messages/y"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewGoogleChatNotifier(GoogleChatConfig{WebhookURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if err := notifier.SendWithOptions(context.Background(), &Message{Title: "Deployment started"}); err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if strings.Contains(string(body), `"sections"`) {
+		t.Errorf("Expected no sections for a title-only card, got %s", body)
+	}
+}
+
+func TestGoogleChatSendRichMessage(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewGoogleChatNotifier(GoogleChatConfig{WebhookURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	card := json.RawMessage(`{"cardId":"c1","card":{"sections":[{"widgets":[{"textParagraph":{"text":"hi"}}]}]}}`)
+	if err := notifier.SendRichMessage(context.Background(), "", card); err != nil {
+		t.Fatalf("SendRichMessage failed: %v", err)
+	}
+
+	if _, ok := body["cardsV2"]; !ok {
+		t.Error("Expected single card to be wrapped in cardsV2")
+	}
+
+	if err := notifier.SendRichMessage(context.Background(), "", 42); err == nil {
+		t.Error("Expected error for unsupported blocks type")
+	}
+}
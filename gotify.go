@@ -0,0 +1,216 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// GotifyNotifier sends push notifications via a Gotify server
+type GotifyNotifier struct {
+	name      string
+	serverURL string
+	appToken  string
+	markdown  bool
+	client    *http.Client
+}
+
+// GotifyConfig holds configuration for Gotify notifications
+type GotifyConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "gotify")
+	Name string
+
+	// ServerURL is the Gotify server URL (e.g., https://gotify.example.com)
+	ServerURL string
+
+	// AppToken is the application token messages are published with
+	AppToken string
+
+	// Markdown renders messages as markdown in Gotify clients (optional)
+	Markdown bool
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type gotifyMessage struct {
+	Title    string                 `json:"title,omitempty"`
+	Message  string                 `json:"message"`
+	Priority int                    `json:"priority"`
+	Extras   map[string]interface{} `json:"extras,omitempty"`
+}
+
+// NewGotifyNotifier creates a new Gotify notifier
+func NewGotifyNotifier(config GotifyConfig) (*GotifyNotifier, error) {
+	if config.ServerURL == "" {
+		return nil, &NotificationError{
+			Provider: "gotify",
+			Message:  "server URL is required",
+		}
+	}
+
+	if config.AppToken == "" {
+		return nil, &NotificationError{
+			Provider: "gotify",
+			Message:  "app token is required",
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "gotify"
+	}
+
+	return &GotifyNotifier{
+		name:      name,
+		serverURL: strings.TrimRight(config.ServerURL, "/"),
+		appToken:  config.AppToken,
+		markdown:  config.Markdown,
+		client:    newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (g *GotifyNotifier) Name() string {
+	return g.name
+}
+
+// Send sends a simple text message
+func (g *GotifyNotifier) Send(ctx context.Context, message string) error {
+	return g.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Metadata["priority"] (0–10) overrides the priority mapping, Metadata["click"]
+// sets the URL opened when the notification is clicked and Metadata["markdown"]
+// overrides the configured content type.
+func (g *GotifyNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if msg.Text == "" {
+		return &NotificationError{
+			Provider: "gotify",
+			Message:  "message text is required",
+		}
+	}
+
+	priority, ok := metadataInt(msg, "priority")
+	if !ok || priority < 0 || priority > 10 {
+		priority = gotifyPriority(msg.Priority)
+	}
+
+	markdown := g.markdown
+	if v, ok := msg.Metadata["markdown"].(bool); ok {
+		markdown = v
+	}
+
+	text := msg.Text
+	if len(msg.Attachments) > 0 {
+		if markdown {
+			text += "\n" + markdownAttachments(msg.Attachments)
+		} else {
+			text += "\n" + plainTextAttachments(msg.Attachments)
+		}
+	}
+
+	payload := &gotifyMessage{
+		Title:    msg.Title,
+		Message:  text,
+		Priority: priority,
+		Extras:   map[string]interface{}{},
+	}
+
+	if markdown {
+		payload.Extras["client::display"] = map[string]interface{}{"contentType": "text/markdown"}
+	}
+
+	if click := metadataString(msg, "click"); click != "" {
+		payload.Extras["client::notification"] = map[string]interface{}{
+			"click": map[string]interface{}{"url": click},
+		}
+	}
+
+	if len(payload.Extras) == 0 {
+		payload.Extras = nil
+	}
+
+	return g.publish(ctx, payload)
+}
+
+// SendRichMessage publishes a raw message.
+// blocks must be a map[string]interface{} following the Gotify message schema.
+func (g *GotifyNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	payload, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "gotify",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	if _, ok := payload["message"]; !ok {
+		return &NotificationError{
+			Provider: "gotify",
+			Message:  "message is required",
+		}
+	}
+
+	return g.publish(ctx, payload)
+}
+
+// publish posts a message to the /message endpoint
+func (g *GotifyNotifier) publish(ctx context.Context, payload interface{}) error {
+	header := http.Header{}
+	header.Set("X-Gotify-Key", g.appToken)
+
+	return sendJSON(ctx, g.client, "gotify", http.MethodPost, g.serverURL+"/message", header, payload, nil)
+}
+
+// gotifyPriority maps a message priority to a Gotify priority (0–10)
+func gotifyPriority(priority string) int {
+	switch priority {
+	case PriorityHigh:
+		return 8
+	case PriorityLow:
+		return 2
+	default:
+		return 5
+	}
+}
+
+// markdownAttachments renders attachments as markdown for providers that support it
+func markdownAttachments(attachments []Attachment) string {
+	var sb strings.Builder
+
+	for _, att := range attachments {
+		if att.Title != "" {
+			sb.WriteString("\n**")
+			sb.WriteString(att.Title)
+			sb.WriteString("**")
+		}
+		if att.Text != "" {
+			sb.WriteString("\n")
+			sb.WriteString(att.Text)
+		}
+		for _, field := range att.Fields {
+			sb.WriteString("\n- **")
+			sb.WriteString(field.Title)
+			sb.WriteString(":** ")
+			sb.WriteString(field.Value)
+		}
+		if att.ImageURL != "" {
+			sb.WriteString("\n![")
+			sb.WriteString(att.Title)
+			sb.WriteString("](")
+			sb.WriteString(att.ImageURL)
+			sb.WriteString(")")
+		}
+		if att.Footer != "" {
+			sb.WriteString("\n_")
+			sb.WriteString(att.Footer)
+			sb.WriteString("_")
+		}
+	}
+
+	return strings.TrimPrefix(sb.String(), "\n")
+}
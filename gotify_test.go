@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGotifyMarkdownExtras(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Gotify-Key") != "app" {
+			t.Errorf("Unexpected X-Gotify-Key header: %s", r.Header.Get("X-Gotify-Key"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewGotifyNotifier(GotifyConfig{ServerURL: server.URL, AppToken: "app", Markdown: true})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if err := notifier.SendWithOptions(context.Background(), &Message{Text: "**done**", Priority: PriorityLow}); err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if body["priority"] != float64(2) {
+		t.Errorf("Expected priority 2, got %v", body["priority"])
+	}
+
+	extras := body["extras"].(map[string]interface{})
+	display := extras["client::display"].(map[string]interface{})
+	if display["contentType"] != "text/markdown" {
+		t.Errorf("Expected markdown content type, got %v", display["contentType"])
+	}
+}
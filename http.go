@@ -0,0 +1,217 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// defaultHTTPTimeout is used when a provider config does not supply an HTTP client
+const defaultHTTPTimeout = 30 * time.Second
+
+// newHTTPClient returns client, or a client with the default timeout if nil
+func newHTTPClient(client *http.Client) *http.Client {
+	if client != nil {
+		return client
+	}
+	return &http.Client{
+		Timeout: defaultHTTPTimeout,
+	}
+}
+
+// doRequest executes req and returns the status code and response body
+func doRequest(client *http.Client, provider string, req *http.Request) (int, []byte, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, &NotificationError{
+			Provider: provider,
+			Message:  "failed to send request",
+			Err:      err,
+		}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, nil, &NotificationError{
+			Provider: provider,
+			Message:  "failed to read response",
+			Err:      err,
+		}
+	}
+
+	return resp.StatusCode, body, nil
+}
+
+// sendJSON sends payload as a JSON request and decodes the response into out (if non-nil).
+// Any non-2xx status is reported as a NotificationError that includes the response body.
+func sendJSON(ctx context.Context, client *http.Client, provider, method, endpoint string, header http.Header, payload, out interface{}) error {
+	var reqBody io.Reader
+	if payload != nil {
+		jsonData, err := json.Marshal(payload)
+		if err != nil {
+			return &NotificationError{
+				Provider: provider,
+				Message:  "failed to marshal request",
+				Err:      err,
+			}
+		}
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return &NotificationError{
+			Provider: provider,
+			Message:  "failed to create request",
+			Err:      err,
+		}
+	}
+
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return decodeResponse(client, provider, req, out)
+}
+
+// sendForm posts form as application/x-www-form-urlencoded and decodes the JSON response into out (if non-nil).
+// Any non-2xx status is reported as a NotificationError that includes the response body.
+func sendForm(ctx context.Context, client *http.Client, provider, endpoint string, header http.Header, form url.Values, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return &NotificationError{
+			Provider: provider,
+			Message:  "failed to create request",
+			Err:      err,
+		}
+	}
+
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	return decodeResponse(client, provider, req, out)
+}
+
+// decodeResponse executes req and decodes the JSON response into out (if non-nil)
+func decodeResponse(client *http.Client, provider string, req *http.Request, out interface{}) error {
+	status, body, err := doRequest(client, provider, req)
+	if err != nil {
+		return err
+	}
+
+	if status < 200 || status > 299 {
+		return &NotificationError{
+			Provider: provider,
+			Message:  fmt.Sprintf("API request failed with status %d: %s", status, string(body)),
+		}
+	}
+
+	if out == nil || len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return &NotificationError{
+			Provider: provider,
+			Message:  "failed to parse response",
+			Err:      err,
+		}
+	}
+
+	return nil
+}
+
+// truncate shortens s to at most limit characters, ending with "..." when it is cut.
+// It counts runes so multi-byte characters are never split.
+func truncate(s string, limit int) string {
+	if utf8.RuneCountInString(s) <= limit {
+		return s
+	}
+
+	runes := []rune(s)
+	return string(runes[:limit-3]) + "..."
+}
+
+// metadataString returns the string value stored under key in msg.Metadata
+func metadataString(msg *Message, key string) string {
+	if msg == nil || msg.Metadata == nil {
+		return ""
+	}
+
+	switch v := msg.Metadata[key].(type) {
+	case string:
+		return v
+	case fmt.Stringer:
+		return v.String()
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// metadataStrings returns the value stored under key in msg.Metadata as a string slice.
+// Both []string and []interface{} values are accepted, as well as a single string.
+func metadataStrings(msg *Message, key string) []string {
+	if msg == nil || msg.Metadata == nil {
+		return nil
+	}
+
+	switch v := msg.Metadata[key].(type) {
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+		return values
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	default:
+		return nil
+	}
+}
+
+// metadataInt returns the integer value stored under key in msg.Metadata.
+// The second return value reports whether a valid integer was found.
+func metadataInt(msg *Message, key string) (int, bool) {
+	if msg == nil || msg.Metadata == nil {
+		return 0, false
+	}
+
+	switch v := msg.Metadata[key].(type) {
+	case int:
+		return v, true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	case string:
+		n, err := strconv.Atoi(v)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,870 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ircMaxLine is the maximum IRC line length including the trailing CRLF
+const ircMaxLine = 512
+
+// ircMaxBackoff caps the delay between reconnection attempts
+const ircMaxBackoff = time.Minute
+
+// errIRCClosed is returned when sending through a closed notifier
+var errIRCClosed = errors.New("notifier is closed")
+
+// IRCNotifier sends notifications to IRC channels and users over a persistent connection.
+// The connection is opened on the first notification (or by Connect), channels are joined
+// when first used, and dropped connections are re-established with exponential backoff.
+type IRCNotifier struct {
+	name           string
+	server         string
+	disableTLS     bool
+	tlsConfig      *tls.Config
+	password       string
+	nick           string
+	username       string
+	realName       string
+	saslUsername   string
+	saslPassword   string
+	defaultChannel string
+	floodDelay     time.Duration
+	floodBurst     int
+	reconnectDelay time.Duration
+	maxAttempts    int
+	timeout        time.Duration
+	quitMessage    string
+
+	// mu guards conn; it is never held while waiting for flood control or writing messages
+	mu   sync.Mutex
+	conn *ircConn
+
+	// sending serializes deliveries so lines of different messages don't interleave;
+	// nextSend is only used by its holder
+	sending  chan struct{}
+	nextSend time.Time
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// IRCConfig holds configuration for IRC notifications
+type IRCConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "irc")
+	Name string
+
+	// Server is the host:port of the IRC server (e.g., irc.libera.chat:6697)
+	Server string
+
+	// Nick is the nickname to use
+	Nick string
+
+	// Username is the ident username (optional, defaults to Nick)
+	Username string
+
+	// RealName is the real name/gecos (optional, defaults to Nick)
+	RealName string
+
+	// Password is the server password sent with PASS (optional)
+	Password string
+
+	// SASLUsername and SASLPassword enable SASL PLAIN authentication (optional)
+	SASLUsername string
+	SASLPassword string
+
+	// DefaultChannel is used when Message.Channel is empty (optional)
+	DefaultChannel string
+
+	// TLSConfig allows custom TLS settings (optional)
+	TLSConfig *tls.Config
+
+	// DisableTLS connects without TLS (optional, not recommended)
+	DisableTLS bool
+
+	// FloodDelay is the sustained delay between lines (optional, defaults to 2s)
+	FloodDelay time.Duration
+
+	// FloodBurst is the number of lines that may be sent without delay (optional, defaults to 4)
+	FloodBurst int
+
+	// ReconnectDelay is the initial reconnection backoff, doubled after each failure (optional, defaults to 1s)
+	ReconnectDelay time.Duration
+
+	// MaxReconnectAttempts limits connection attempts per notification (optional, defaults to 5)
+	MaxReconnectAttempts int
+
+	// Timeout for registration and channel joins (optional, defaults to 30s)
+	Timeout time.Duration
+
+	// QuitMessage is sent when the notifier is closed (optional)
+	QuitMessage string
+}
+
+// ircConn is a single registered connection
+type ircConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+
+	writeMu sync.Mutex
+	timeout time.Duration
+
+	mu       sync.Mutex
+	nick     string
+	joined   map[string]bool
+	joins    map[string]chan error
+	writeErr error
+
+	done chan struct{}
+	err  error
+}
+
+// ircMessage is a parsed IRC protocol line
+type ircMessage struct {
+	Prefix  string
+	Command string
+	Params  []string
+}
+
+// NewIRCNotifier creates a new IRC notifier
+func NewIRCNotifier(config IRCConfig) (*IRCNotifier, error) {
+	if config.Server == "" {
+		return nil, &NotificationError{
+			Provider: "irc",
+			Message:  "server is required",
+		}
+	}
+
+	if config.Nick == "" {
+		return nil, &NotificationError{
+			Provider: "irc",
+			Message:  "nick is required",
+		}
+	}
+
+	if (config.SASLUsername == "") != (config.SASLPassword == "") {
+		return nil, &NotificationError{
+			Provider: "irc",
+			Message:  "SASL username and password must be set together",
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "irc"
+	}
+
+	n := &IRCNotifier{
+		name:           name,
+		server:         config.Server,
+		disableTLS:     config.DisableTLS,
+		tlsConfig:      config.TLSConfig,
+		password:       config.Password,
+		nick:           config.Nick,
+		username:       config.Username,
+		realName:       config.RealName,
+		saslUsername:   config.SASLUsername,
+		saslPassword:   config.SASLPassword,
+		defaultChannel: config.DefaultChannel,
+		floodDelay:     config.FloodDelay,
+		floodBurst:     config.FloodBurst,
+		reconnectDelay: config.ReconnectDelay,
+		maxAttempts:    config.MaxReconnectAttempts,
+		timeout:        config.Timeout,
+		quitMessage:    config.QuitMessage,
+		sending:        make(chan struct{}, 1),
+		closed:         make(chan struct{}),
+	}
+
+	if n.username == "" {
+		n.username = n.nick
+	}
+	if n.realName == "" {
+		n.realName = n.nick
+	}
+	if n.floodDelay == 0 {
+		n.floodDelay = 2 * time.Second
+	}
+	if n.floodBurst <= 0 {
+		n.floodBurst = 4
+	}
+	if n.reconnectDelay == 0 {
+		n.reconnectDelay = time.Second
+	}
+	if n.maxAttempts <= 0 {
+		n.maxAttempts = 5
+	}
+	if n.timeout == 0 {
+		n.timeout = defaultHTTPTimeout
+	}
+
+	return n, nil
+}
+
+// Name returns the name of the provider
+func (n *IRCNotifier) Name() string {
+	return n.name
+}
+
+// Connect opens the connection eagerly and ties the notifier's lifetime to ctx:
+// when ctx is cancelled the notifier sends QUIT and closes.
+func (n *IRCNotifier) Connect(ctx context.Context) error {
+	delay := n.reconnectDelay
+	var err error
+	for attempt := 1; attempt <= n.maxAttempts; attempt++ {
+		if attempt > 1 {
+			if err := n.backoff(ctx, &delay); err != nil {
+				return err
+			}
+		}
+
+		if _, err = n.connection(ctx); err == nil || !n.retryable(ctx, err) {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = n.Close()
+		case <-n.closed:
+		}
+	}()
+
+	return nil
+}
+
+// Close sends QUIT and closes the connection. The notifier cannot be used afterwards.
+func (n *IRCNotifier) Close() error {
+	n.closeOnce.Do(func() {
+		close(n.closed)
+	})
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		return nil
+	}
+
+	c := n.conn
+	n.conn = nil
+
+	_ = c.write("QUIT :" + n.quitMessage)
+	err := c.conn.Close()
+	<-c.done
+	return err
+}
+
+// Send sends a simple text message
+func (n *IRCNotifier) Send(ctx context.Context, message string) error {
+	return n.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Message.Channel is a channel or nick; the title is sent in bold before the
+// text and attachments, and long lines are split to fit the IRC line limit.
+// Set Metadata "notice" to true to send NOTICEs instead of PRIVMSGs.
+func (n *IRCNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if msg.Text == "" {
+		return &NotificationError{
+			Provider: "irc",
+			Message:  "message text is required",
+		}
+	}
+
+	text := msg.Text
+	if msg.Title != "" {
+		text = "\x02" + msg.Title + "\x02\n" + text
+	}
+	if len(msg.Attachments) > 0 {
+		text += "\n" + plainTextAttachments(msg.Attachments)
+	}
+
+	command := "PRIVMSG"
+	if notice, _ := msg.Metadata["notice"].(bool); notice {
+		command = "NOTICE"
+	}
+
+	return n.deliver(ctx, msg.Channel, command, text)
+}
+
+// SendRichMessage sends pre-formatted text containing IRC formatting codes.
+// blocks must be a string; it is split into lines like a regular message.
+func (n *IRCNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	text, ok := blocks.(string)
+	if !ok {
+		return &NotificationError{
+			Provider: "irc",
+			Message:  "blocks must be of type string",
+		}
+	}
+
+	return n.deliver(ctx, channel, "PRIVMSG", text)
+}
+
+// deliver sends text to target, reconnecting and resuming from the first unsent line on connection loss
+func (n *IRCNotifier) deliver(ctx context.Context, target, command, text string) error {
+	if target == "" {
+		target = n.defaultChannel
+	}
+	if target == "" || strings.ContainsAny(target, " ,\r\n") {
+		return &NotificationError{
+			Provider: "irc",
+			Message:  fmt.Sprintf("invalid target %q", target),
+		}
+	}
+
+	select {
+	case n.sending <- struct{}{}:
+		defer func() { <-n.sending }()
+	case <-ctx.Done():
+		return &NotificationError{Provider: "irc", Message: "context done", Err: ctx.Err()}
+	case <-n.closed:
+		return &NotificationError{Provider: "irc", Message: "failed to send", Err: errIRCClosed}
+	}
+
+	delay := n.reconnectDelay
+	sent := 0
+	var lines []string
+	var err error
+	for attempt := 1; attempt <= n.maxAttempts; attempt++ {
+		if attempt > 1 {
+			if err := n.backoff(ctx, &delay); err != nil {
+				return err
+			}
+		}
+
+		var c *ircConn
+		if c, err = n.connection(ctx); err != nil {
+			if !n.retryable(ctx, err) {
+				return err
+			}
+			continue
+		}
+
+		if lines == nil {
+			lines = ircSplit(text, ircMaxLine-ircOverhead(c.currentNick(), command, target))
+		}
+
+		if err = n.sendLines(ctx, c, target, command, lines, &sent); err == nil {
+			return nil
+		}
+
+		// Retry only when the connection was lost; other errors (e.g., a refused join) are final
+		if !c.lost() {
+			return err
+		}
+		n.drop(c)
+	}
+
+	return &NotificationError{
+		Provider: "irc",
+		Message:  fmt.Sprintf("failed to send after %d attempts", n.maxAttempts),
+		Err:      err,
+	}
+}
+
+// sendLines joins target if needed and sends the remaining lines with flood protection
+func (n *IRCNotifier) sendLines(ctx context.Context, c *ircConn, target, command string, lines []string, sent *int) error {
+	if isIRCChannel(target) {
+		if err := n.join(ctx, c, target); err != nil {
+			return err
+		}
+	}
+
+	for *sent < len(lines) {
+		if err := n.throttle(ctx); err != nil {
+			return err
+		}
+		if err := c.write(fmt.Sprintf("%s %s :%s", command, target, lines[*sent])); err != nil {
+			return err
+		}
+		*sent++
+	}
+
+	return nil
+}
+
+// join joins channel unless already joined and waits for the server to confirm
+func (n *IRCNotifier) join(ctx context.Context, c *ircConn, channel string) error {
+	key := strings.ToLower(channel)
+
+	c.mu.Lock()
+	if c.joined[key] {
+		c.mu.Unlock()
+		return nil
+	}
+	result := make(chan error, 1)
+	c.joins[key] = result
+	c.mu.Unlock()
+
+	if err := n.throttle(ctx); err != nil {
+		return err
+	}
+	if err := c.write("JOIN " + channel); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(n.timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-result:
+		return err
+	case <-c.done:
+		return c.closeError()
+	case <-ctx.Done():
+		return &NotificationError{Provider: "irc", Message: "context done", Err: ctx.Err()}
+	case <-n.closed:
+		return &NotificationError{Provider: "irc", Message: "failed to join " + channel, Err: errIRCClosed}
+	case <-timer.C:
+		return &NotificationError{Provider: "irc", Message: "timed out joining " + channel}
+	}
+}
+
+// throttle waits until another line may be sent without exceeding the flood limit.
+// Each line advances a virtual clock by floodDelay; lines are sent immediately while
+// the clock is less than floodBurst lines ahead of real time.
+func (n *IRCNotifier) throttle(ctx context.Context) error {
+	now := time.Now()
+	if n.nextSend.Before(now) {
+		n.nextSend = now
+	}
+
+	if wait := n.nextSend.Sub(now) - time.Duration(n.floodBurst-1)*n.floodDelay; wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return &NotificationError{Provider: "irc", Message: "context done", Err: ctx.Err()}
+		case <-n.closed:
+			return &NotificationError{Provider: "irc", Message: "failed to send", Err: errIRCClosed}
+		}
+	}
+
+	n.nextSend = n.nextSend.Add(n.floodDelay)
+	return nil
+}
+
+// connection returns the live connection, dialing once if there is none
+func (n *IRCNotifier) connection(ctx context.Context) (*ircConn, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	select {
+	case <-n.closed:
+		return nil, &NotificationError{Provider: "irc", Message: "failed to connect", Err: errIRCClosed}
+	default:
+	}
+
+	if n.conn != nil {
+		if !n.conn.lost() {
+			return n.conn, nil
+		}
+		n.conn = nil
+	}
+
+	c, err := n.dial(ctx)
+	if err != nil {
+		return nil, &NotificationError{Provider: "irc", Message: "failed to connect", Err: err}
+	}
+
+	n.conn = c
+	return c, nil
+}
+
+// drop discards c if it is still the current connection
+func (n *IRCNotifier) drop(c *ircConn) {
+	n.mu.Lock()
+	if n.conn == c {
+		n.conn = nil
+	}
+	n.mu.Unlock()
+
+	_ = c.conn.Close()
+}
+
+// retryable reports whether another connection attempt may succeed after err
+func (n *IRCNotifier) retryable(ctx context.Context, err error) bool {
+	return ctx.Err() == nil && !errors.Is(err, errIRCClosed)
+}
+
+// backoff waits delay before the next connection attempt and doubles it, up to ircMaxBackoff
+func (n *IRCNotifier) backoff(ctx context.Context, delay *time.Duration) error {
+	timer := time.NewTimer(*delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		return &NotificationError{Provider: "irc", Message: "context done", Err: ctx.Err()}
+	case <-n.closed:
+		return &NotificationError{Provider: "irc", Message: "failed to connect", Err: errIRCClosed}
+	}
+
+	if *delay *= 2; *delay > ircMaxBackoff {
+		*delay = ircMaxBackoff
+	}
+	return nil
+}
+
+// dial connects and registers with the server, then starts the read loop
+func (n *IRCNotifier) dial(ctx context.Context) (*ircConn, error) {
+	dialer := &net.Dialer{Timeout: n.timeout}
+
+	var conn net.Conn
+	var err error
+	if n.disableTLS {
+		conn, err = dialer.DialContext(ctx, "tcp", n.server)
+	} else {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: n.tlsConfig}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", n.server)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &ircConn{
+		conn:    conn,
+		reader:  bufio.NewReader(conn),
+		nick:    n.nick,
+		timeout: n.timeout,
+		joined:  make(map[string]bool),
+		joins:   make(map[string]chan error),
+		done:    make(chan struct{}),
+	}
+
+	_ = conn.SetDeadline(time.Now().Add(n.timeout))
+	if err := n.register(c); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	_ = conn.SetDeadline(time.Time{})
+
+	go c.readLoop()
+
+	return c, nil
+}
+
+// register performs capability negotiation, SASL authentication and registration
+func (n *IRCNotifier) register(c *ircConn) error {
+	if n.password != "" {
+		if err := c.write("PASS " + n.password); err != nil {
+			return err
+		}
+	}
+	if n.saslUsername != "" {
+		if err := c.write("CAP REQ :sasl"); err != nil {
+			return err
+		}
+	}
+	if err := c.write("NICK " + c.nick); err != nil {
+		return err
+	}
+	if err := c.write(fmt.Sprintf("USER %s 0 * :%s", n.username, n.realName)); err != nil {
+		return err
+	}
+
+	authenticated := false
+	for {
+		msg, err := c.readMessage()
+		if err != nil {
+			return err
+		}
+
+		switch msg.Command {
+		case "PING":
+			err = c.write("PONG :" + msg.param(0))
+		case "CAP":
+			switch msg.param(1) {
+			case "ACK":
+				err = c.write("AUTHENTICATE PLAIN")
+			case "NAK":
+				return fmt.Errorf("server does not support SASL")
+			}
+		case "AUTHENTICATE":
+			if msg.param(0) == "+" {
+				err = n.authenticate(c)
+			}
+		case "903":
+			authenticated = true
+			err = c.write("CAP END")
+		case "902", "904", "905", "906", "908":
+			return fmt.Errorf("SASL authentication failed: %s", msg.param(len(msg.Params)-1))
+		case "432", "433", "436":
+			// Nickname invalid or in use during registration: try an alternative
+			c.nick += "_"
+			err = c.write("NICK " + c.nick)
+		case "001":
+			// Servers that ignore CAP complete registration without authenticating
+			if n.saslUsername != "" && !authenticated {
+				return fmt.Errorf("server completed registration without SASL authentication")
+			}
+			c.nick = msg.param(0)
+			return nil
+		case "ERROR":
+			return fmt.Errorf("server error: %s", msg.param(0))
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// authenticate sends the SASL PLAIN credentials in 400-byte chunks
+func (n *IRCNotifier) authenticate(c *ircConn) error {
+	payload := base64.StdEncoding.EncodeToString([]byte(n.saslUsername + "\x00" + n.saslUsername + "\x00" + n.saslPassword))
+
+	for len(payload) >= 400 {
+		if err := c.write("AUTHENTICATE " + payload[:400]); err != nil {
+			return err
+		}
+		payload = payload[400:]
+	}
+	if payload == "" {
+		payload = "+"
+	}
+
+	return c.write("AUTHENTICATE " + payload)
+}
+
+// readLoop handles server messages until the connection fails
+func (c *ircConn) readLoop() {
+	var err error
+	defer func() {
+		c.mu.Lock()
+		c.err = err
+		c.mu.Unlock()
+		c.conn.Close()
+		close(c.done)
+	}()
+
+	for {
+		var msg *ircMessage
+		if msg, err = c.readMessage(); err != nil {
+			return
+		}
+
+		switch msg.Command {
+		case "PING":
+			if err = c.write("PONG :" + msg.param(0)); err != nil {
+				return
+			}
+		case "JOIN":
+			if c.isSelf(msg.Prefix) {
+				c.resolveJoin(msg.param(0), nil)
+			}
+		case "PART":
+			if c.isSelf(msg.Prefix) {
+				c.forget(msg.param(0))
+			}
+		case "KICK":
+			if strings.EqualFold(msg.param(1), c.currentNick()) {
+				c.forget(msg.param(0))
+			}
+		case "NICK":
+			if c.isSelf(msg.Prefix) {
+				c.mu.Lock()
+				c.nick = msg.param(0)
+				c.mu.Unlock()
+			}
+		case "403", "405", "471", "473", "474", "475", "477":
+			// Join failures: <nick> <channel> :<reason>
+			c.resolveJoin(msg.param(1), fmt.Errorf("%s", msg.param(2)))
+		case "ERROR":
+			err = fmt.Errorf("server error: %s", msg.param(0))
+			return
+		}
+	}
+}
+
+// resolveJoin marks channel joined (err == nil) and wakes the waiting sender
+func (c *ircConn) resolveJoin(channel string, err error) {
+	key := strings.ToLower(channel)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err == nil {
+		c.joined[key] = true
+	}
+	if result, ok := c.joins[key]; ok {
+		delete(c.joins, key)
+		if err != nil {
+			err = &NotificationError{Provider: "irc", Message: "failed to join " + channel, Err: err}
+		}
+		result <- err
+	}
+}
+
+// forget marks channel as no longer joined
+func (c *ircConn) forget(channel string) {
+	c.mu.Lock()
+	delete(c.joined, strings.ToLower(channel))
+	c.mu.Unlock()
+}
+
+// isSelf reports whether prefix refers to our nick
+func (c *ircConn) isSelf(prefix string) bool {
+	nick, _, _ := strings.Cut(prefix, "!")
+	return strings.EqualFold(nick, c.currentNick())
+}
+
+// currentNick returns the nick the server knows us by
+func (c *ircConn) currentNick() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.nick
+}
+
+// closeError returns why the connection was closed
+func (c *ircConn) closeError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &NotificationError{Provider: "irc", Message: "connection lost", Err: c.err}
+}
+
+// write sends a single line. A failed or timed out write closes the connection,
+// since the server may have received part of the line.
+func (c *ircConn) write(line string) error {
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	if _, err := c.conn.Write([]byte(line + "\r\n")); err != nil {
+		c.mu.Lock()
+		c.writeErr = err
+		c.mu.Unlock()
+		_ = c.conn.Close()
+
+		return &NotificationError{Provider: "irc", Message: "failed to write", Err: err}
+	}
+	return nil
+}
+
+// lost reports whether the connection has failed
+func (c *ircConn) lost() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.writeErr != nil
+}
+
+// readMessage reads and parses the next line
+func (c *ircConn) readMessage() (*ircMessage, error) {
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		if msg := parseIRCMessage(line); msg != nil {
+			return msg, nil
+		}
+	}
+}
+
+// param returns the i-th parameter or ""
+func (m *ircMessage) param(i int) string {
+	if i < 0 || i >= len(m.Params) {
+		return ""
+	}
+	return m.Params[i]
+}
+
+// parseIRCMessage parses a raw line, ignoring IRCv3 tags. It returns nil for empty lines.
+func parseIRCMessage(line string) *ircMessage {
+	line = strings.TrimRight(line, "\r\n")
+	if strings.HasPrefix(line, "@") {
+		_, line, _ = strings.Cut(line, " ")
+	}
+	if line == "" {
+		return nil
+	}
+
+	msg := &ircMessage{}
+	if strings.HasPrefix(line, ":") {
+		msg.Prefix, line, _ = strings.Cut(line[1:], " ")
+	}
+
+	for line != "" {
+		if strings.HasPrefix(line, ":") {
+			msg.Params = append(msg.Params, line[1:])
+			break
+		}
+		var param string
+		param, line, _ = strings.Cut(line, " ")
+		if param == "" {
+			continue
+		}
+		if msg.Command == "" {
+			msg.Command = strings.ToUpper(param)
+		} else {
+			msg.Params = append(msg.Params, param)
+		}
+	}
+
+	return msg
+}
+
+// ircSplit splits text into lines of at most maxBytes, breaking at spaces where
+// possible and never inside a UTF-8 sequence. Empty lines are dropped.
+func ircSplit(text string, maxBytes int) []string {
+	if maxBytes < 16 {
+		maxBytes = 16
+	}
+
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r", ""), "\n") {
+		for len(line) > maxBytes {
+			cut := maxBytes
+			for cut > 0 && !utf8.RuneStart(line[cut]) {
+				cut--
+			}
+			if space := strings.LastIndexByte(line[:cut], ' '); space > maxBytes/2 {
+				cut = space
+			}
+			lines = append(lines, line[:cut])
+			line = strings.TrimLeft(line[cut:], " ")
+		}
+		if strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
+
+// ircOverhead returns the bytes of a relayed "command target :text" line not
+// available for text: the worst-case ":nick!user@host " prefix the server adds,
+// the command and target, and the trailing CRLF
+func ircOverhead(nick, command, target string) int {
+	return 1 + len(nick) + 1 + 10 + 1 + 63 + 1 + len(command) + 1 + len(target) + 2 + 2
+}
+
+// isIRCChannel reports whether target is a channel name
+func isIRCChannel(target string) bool {
+	return strings.HasPrefix(target, "#") || strings.HasPrefix(target, "&")
+}
@@ -0,0 +1,381 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"net"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// ircTestServer accepts TLS connections and records every client line
+type ircTestServer struct {
+	listener net.Listener
+	lines    chan string
+	conns    chan net.Conn
+	roots    *x509.CertPool
+	closeTLS func()
+
+	// stall makes the server stop reading after a JOIN until it is closed
+	stall   bool
+	stopped chan struct{}
+
+	// noCAP makes the server ignore CAP and register clients on USER
+	noCAP bool
+}
+
+func newIRCTestServer(t *testing.T) *ircTestServer {
+	t.Helper()
+
+	// Reuse the httptest certificate, which is valid for 127.0.0.1
+	tlsServer := httptest.NewTLSServer(nil)
+	roots := x509.NewCertPool()
+	roots.AddCert(tlsServer.Certificate())
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: tlsServer.TLS.Certificates})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+
+	s := &ircTestServer{
+		listener: listener,
+		lines:    make(chan string, 100),
+		conns:    make(chan net.Conn, 10),
+		roots:    roots,
+		closeTLS: tlsServer.Close,
+		stopped:  make(chan struct{}),
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			s.conns <- conn
+			go s.handle(conn)
+		}
+	}()
+
+	return s
+}
+
+func (s *ircTestServer) close() {
+	close(s.stopped)
+	s.listener.Close()
+	s.closeTLS()
+}
+
+// handle implements just enough of a server for SASL registration and joins
+func (s *ircTestServer) handle(conn net.Conn) {
+	defer conn.Close()
+
+	nick := ""
+	reply := func(line string) {
+		_, _ = conn.Write([]byte(line + "\r\n"))
+	}
+
+	scanner := bufio.NewScanner(conn)
+	for scanner.Scan() {
+		line := scanner.Text()
+		s.lines <- line
+
+		msg := parseIRCMessage(line)
+		switch msg.Command {
+		case "NICK":
+			nick = msg.param(0)
+		case "USER":
+			if s.noCAP {
+				reply(":irc.test 001 " + nick + " :Welcome")
+			}
+		case "CAP":
+			if s.noCAP {
+				continue
+			}
+			if msg.param(0) == "REQ" {
+				reply(":irc.test CAP * ACK :sasl")
+			} else if msg.param(0) == "END" {
+				reply(":irc.test 001 " + nick + " :Welcome")
+			}
+		case "AUTHENTICATE":
+			if msg.param(0) == "PLAIN" {
+				reply("AUTHENTICATE +")
+				continue
+			}
+			credentials, _ := base64.StdEncoding.DecodeString(msg.param(0))
+			if string(credentials) == "bot\x00bot\x00secret" {
+				reply(":irc.test 903 " + nick + " :SASL authentication successful")
+			} else {
+				reply(":irc.test 904 " + nick + " :SASL authentication failed")
+			}
+		case "JOIN":
+			if msg.param(0) == "#banned" {
+				reply(":irc.test 474 " + nick + " #banned :Cannot join channel (+b)")
+			} else {
+				reply(":" + nick + "!bot@test JOIN " + msg.param(0))
+			}
+			if s.stall {
+				<-s.stopped
+				return
+			}
+		case "QUIT":
+			return
+		}
+	}
+}
+
+// next returns the next line with the given command, skipping others
+func (s *ircTestServer) next(t *testing.T, command string) string {
+	t.Helper()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case line := <-s.lines:
+			if strings.HasPrefix(line, command+" ") {
+				return line
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for %s", command)
+			return ""
+		}
+	}
+}
+
+func (s *ircTestServer) config() IRCConfig {
+	return IRCConfig{
+		Server:         s.listener.Addr().String(),
+		Nick:           "bot",
+		SASLUsername:   "bot",
+		SASLPassword:   "secret",
+		DefaultChannel: "#ops",
+		TLSConfig:      &tls.Config{RootCAs: s.roots},
+		FloodDelay:     time.Millisecond,
+		ReconnectDelay: time.Millisecond,
+		Timeout:        5 * time.Second,
+	}
+}
+
+func TestNewIRCNotifier(t *testing.T) {
+	_, err := NewIRCNotifier(IRCConfig{Nick: "bot"})
+	if err == nil {
+		t.Error("Expected error when server is missing")
+	}
+
+	_, err = NewIRCNotifier(IRCConfig{Server: "irc.example.com:6697"})
+	if err == nil {
+		t.Error("Expected error when nick is missing")
+	}
+
+	notifier, err := NewIRCNotifier(IRCConfig{Server: "irc.example.com:6697", Nick: "bot"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "irc" {
+		t.Errorf("Expected name 'irc', got '%s'", notifier.Name())
+	}
+}
+
+func TestIRCSendJoinsLazilyAndSplits(t *testing.T) {
+	server := newIRCTestServer(t)
+	defer server.close()
+
+	notifier, err := NewIRCNotifier(server.config())
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	long := strings.Repeat("word ", 200)
+	err = notifier.SendWithOptions(context.Background(), &Message{Title: "Alert", Text: long})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if line := server.next(t, "AUTHENTICATE"); line != "AUTHENTICATE PLAIN" {
+		t.Errorf("Expected SASL PLAIN, got %s", line)
+	}
+	if line := server.next(t, "JOIN"); line != "JOIN #ops" {
+		t.Errorf("Expected JOIN #ops, got %s", line)
+	}
+
+	if line := server.next(t, "PRIVMSG"); line != "PRIVMSG #ops :\x02Alert\x02" {
+		t.Errorf("Expected bold title line, got %q", line)
+	}
+
+	var received string
+	for len(received) < len(strings.TrimSpace(long)) {
+		line := server.next(t, "PRIVMSG")
+		if len(line) > ircMaxLine-2 {
+			t.Errorf("Line exceeds IRC limit: %d bytes", len(line))
+		}
+		received += strings.TrimPrefix(line, "PRIVMSG #ops :") + " "
+	}
+	if strings.TrimSpace(received) != strings.TrimSpace(long) {
+		t.Error("Split lines do not reassemble to the original text")
+	}
+
+	// The channel is already joined, so the second message must not JOIN again
+	if err := notifier.Send(context.Background(), "second"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if line := server.next(t, "PRIVMSG"); line != "PRIVMSG #ops :second" {
+		t.Errorf("Expected second message, got %q (join sent twice?)", line)
+	}
+}
+
+func TestIRCJoinRefused(t *testing.T) {
+	server := newIRCTestServer(t)
+	defer server.close()
+
+	notifier, err := NewIRCNotifier(server.config())
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	err = notifier.SendWithOptions(context.Background(), &Message{Text: "hi", Channel: "#banned"})
+	if err == nil || !strings.Contains(err.Error(), "+b") {
+		t.Errorf("Expected ban error, got %v", err)
+	}
+}
+
+func TestIRCReconnect(t *testing.T) {
+	server := newIRCTestServer(t)
+	defer server.close()
+
+	notifier, err := NewIRCNotifier(server.config())
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	if err := notifier.Send(context.Background(), "first"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	server.next(t, "PRIVMSG")
+
+	// Drop the connection server-side and wait for the client to notice
+	(<-server.conns).Close()
+	notifier.mu.Lock()
+	done := notifier.conn.done
+	notifier.mu.Unlock()
+	<-done
+
+	if err := notifier.Send(context.Background(), "second"); err != nil {
+		t.Fatalf("Send after disconnect failed: %v", err)
+	}
+	if line := server.next(t, "JOIN"); line != "JOIN #ops" {
+		t.Errorf("Expected channel to be joined again, got %s", line)
+	}
+	if line := server.next(t, "PRIVMSG"); line != "PRIVMSG #ops :second" {
+		t.Errorf("Expected second message, got %q", line)
+	}
+}
+
+func TestIRCConnectQuitsOnCancel(t *testing.T) {
+	server := newIRCTestServer(t)
+	defer server.close()
+
+	config := server.config()
+	config.QuitMessage = "bye"
+	notifier, err := NewIRCNotifier(config)
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := notifier.Connect(ctx); err != nil {
+		t.Fatalf("Connect failed: %v", err)
+	}
+	cancel()
+
+	if line := server.next(t, "QUIT"); line != "QUIT :bye" {
+		t.Errorf("Expected QUIT :bye, got %s", line)
+	}
+
+	if err := notifier.Send(context.Background(), "late"); err == nil {
+		t.Error("Expected error when sending after shutdown")
+	}
+}
+
+func TestIRCRequiresSASL(t *testing.T) {
+	server := newIRCTestServer(t)
+	defer server.close()
+	server.noCAP = true
+
+	config := server.config()
+	config.MaxReconnectAttempts = 1
+	notifier, err := NewIRCNotifier(config)
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	err = notifier.Send(context.Background(), "hi")
+	if err == nil || !strings.Contains(err.Error(), "without SASL authentication") {
+		t.Errorf("Expected SASL error, got %v", err)
+	}
+}
+
+func TestIRCSendStalledServer(t *testing.T) {
+	server := newIRCTestServer(t)
+	defer server.close()
+	server.stall = true
+
+	config := server.config()
+	config.Timeout = 200 * time.Millisecond
+	config.FloodBurst = 1 << 30
+	config.MaxReconnectAttempts = 2
+	notifier, err := NewIRCNotifier(config)
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	// Enough text to fill the socket buffers once the server stops reading
+	result := make(chan error, 1)
+	go func() {
+		result <- notifier.Send(context.Background(), strings.Repeat("word ", 4<<20))
+	}()
+
+	select {
+	case err := <-result:
+		if err == nil || !strings.Contains(err.Error(), "failed to send after 2 attempts") {
+			t.Errorf("Expected write timeout error, got %v", err)
+		}
+	case <-time.After(30 * time.Second):
+		t.Fatal("Send blocked on a stalled server")
+	}
+}
+
+func TestIRCSplit(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected []string
+	}{
+		// Breaks at the last space and drops empty lines
+		{"short\n\nhéllo wörld and more text", []string{"short", "héllo wörld", "and more text"}},
+		// Never splits inside a multi-byte rune
+		{"x" + strings.Repeat("é", 10), []string{"x" + strings.Repeat("é", 7), strings.Repeat("é", 3)}},
+	}
+
+	for _, tt := range tests {
+		lines := ircSplit(tt.text, 16)
+		if len(lines) != len(tt.expected) {
+			t.Errorf("Expected %q, got %q", tt.expected, lines)
+			continue
+		}
+		for i := range tt.expected {
+			if lines[i] != tt.expected[i] {
+				t.Errorf("Line %d: expected %q, got %q", i, tt.expected[i], lines[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,272 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultJournalSocket is the systemd-journald native protocol socket
+const defaultJournalSocket = "/run/systemd/journal/socket"
+
+// journalReservedFields are set by the notifier and can't be overridden by metadata or extra fields
+var journalReservedFields = map[string]bool{
+	"MESSAGE":           true,
+	"PRIORITY":          true,
+	"SYSLOG_IDENTIFIER": true,
+	"NOTIFY_TITLE":      true,
+	"NOTIFY_CHANNEL":    true,
+}
+
+// JournaldNotifier writes notifications to systemd-journald using the native protocol
+type JournaldNotifier struct {
+	name       string
+	socketPath string
+	identifier string
+	fields     map[string]string
+
+	mu   sync.Mutex
+	conn *net.UnixConn
+}
+
+// JournaldConfig holds configuration for journald notifications
+type JournaldConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "journald")
+	Name string
+
+	// SocketPath is the journal socket (optional, defaults to /run/systemd/journal/socket)
+	SocketPath string
+
+	// Identifier is the SYSLOG_IDENTIFIER field (optional, defaults to the program name)
+	Identifier string
+
+	// Fields are extra journal fields added to every entry (optional; MESSAGE, PRIORITY,
+	// SYSLOG_IDENTIFIER, NOTIFY_TITLE and NOTIFY_CHANNEL are reserved)
+	Fields map[string]string
+}
+
+// NewJournaldNotifier creates a new journald notifier
+func NewJournaldNotifier(config JournaldConfig) (*JournaldNotifier, error) {
+	socketPath := config.SocketPath
+	if socketPath == "" {
+		socketPath = defaultJournalSocket
+	}
+
+	identifier := config.Identifier
+	if identifier == "" {
+		identifier = filepath.Base(os.Args[0])
+	}
+
+	fields := make(map[string]string, len(config.Fields))
+	for key, value := range config.Fields {
+		name := journalFieldName(key)
+		if name == "" {
+			return nil, &NotificationError{
+				Provider: "journald",
+				Message:  fmt.Sprintf("invalid journal field name %q", key),
+			}
+		}
+		if journalReservedFields[name] {
+			return nil, &NotificationError{
+				Provider: "journald",
+				Message:  fmt.Sprintf("journal field %s is set by the notifier", name),
+			}
+		}
+		fields[name] = value
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "journald"
+	}
+
+	return &JournaldNotifier{
+		name:       name,
+		socketPath: socketPath,
+		identifier: identifier,
+		fields:     fields,
+	}, nil
+}
+
+// Name returns the name of the provider
+func (j *JournaldNotifier) Name() string {
+	return j.name
+}
+
+// Send sends a simple text message
+func (j *JournaldNotifier) Send(ctx context.Context, message string) error {
+	return j.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Priority sets PRIORITY, the title and channel become NOTIFY_TITLE and
+// NOTIFY_CHANNEL, and Metadata keys become upper-case journal fields
+// (e.g., "thread_key" is written as THREAD_KEY). Metadata can't replace the reserved
+// fields set by the notifier, and keys starting with "_" (trusted fields) are skipped.
+func (j *JournaldNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	fields := map[string]string{
+		"MESSAGE":  logText(msg),
+		"PRIORITY": strconv.Itoa(syslogSeverity(msg.Priority)),
+	}
+	if msg.Title != "" {
+		fields["NOTIFY_TITLE"] = msg.Title
+	}
+	if msg.Channel != "" {
+		fields["NOTIFY_CHANNEL"] = msg.Channel
+	}
+	for key, value := range msg.Metadata {
+		if strings.HasPrefix(key, "_") {
+			continue
+		}
+		if name := journalFieldName(key); name != "" && !journalReservedFields[name] {
+			fields[name] = fmt.Sprint(value)
+		}
+	}
+
+	return j.write(ctx, fields)
+}
+
+// SendRichMessage writes blocks as the MESSAGE field.
+// Strings are sent as-is and anything else is encoded as JSON.
+func (j *JournaldNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	text, err := logBlocks(blocks)
+	if err != nil {
+		return &NotificationError{
+			Provider: "journald",
+			Message:  "failed to marshal blocks",
+			Err:      err,
+		}
+	}
+
+	fields := map[string]string{
+		"MESSAGE":  text,
+		"PRIORITY": strconv.Itoa(syslogSeverity("")),
+	}
+	if channel != "" {
+		fields["NOTIFY_CHANNEL"] = channel
+	}
+
+	return j.write(ctx, fields)
+}
+
+// Close closes the journal socket
+func (j *JournaldNotifier) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.conn == nil {
+		return nil
+	}
+
+	err := j.conn.Close()
+	j.conn = nil
+	return err
+}
+
+// write encodes fields as a single journal entry and sends it as one datagram
+func (j *JournaldNotifier) write(ctx context.Context, fields map[string]string) error {
+	if err := ctx.Err(); err != nil {
+		return &NotificationError{
+			Provider: "journald",
+			Message:  "context done",
+			Err:      err,
+		}
+	}
+
+	// Message fields take precedence over the configured defaults
+	entry := map[string]string{"SYSLOG_IDENTIFIER": j.identifier}
+	for key, value := range j.fields {
+		entry[key] = value
+	}
+	for key, value := range fields {
+		entry[key] = value
+	}
+
+	data := encodeJournalEntry(entry)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if j.conn == nil {
+		conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: j.socketPath, Net: "unixgram"})
+		if err != nil {
+			return &NotificationError{
+				Provider: "journald",
+				Message:  "failed to connect to journal socket",
+				Err:      err,
+			}
+		}
+		j.conn = conn
+	}
+
+	if _, err := j.conn.Write(data); err != nil {
+		j.conn.Close()
+		j.conn = nil
+		return &NotificationError{
+			Provider: "journald",
+			Message:  fmt.Sprintf("failed to write %d byte entry", len(data)),
+			Err:      err,
+		}
+	}
+
+	return nil
+}
+
+// encodeJournalEntry serializes fields using the journal native protocol.
+// Values containing newlines are written as the field name, a newline, a
+// little-endian 64-bit length and the raw value.
+func encodeJournalEntry(fields map[string]string) []byte {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, key := range keys {
+		value := fields[key]
+		if !strings.Contains(value, "\n") {
+			fmt.Fprintf(&buf, "%s=%s\n", key, value)
+			continue
+		}
+
+		buf.WriteString(key)
+		buf.WriteByte('\n')
+		_ = binary.Write(&buf, binary.LittleEndian, uint64(len(value)))
+		buf.WriteString(value)
+		buf.WriteByte('\n')
+	}
+
+	return buf.Bytes()
+}
+
+// journalFieldName converts name to a valid journal field name (A-Z, 0-9 and
+// underscore, not starting with an underscore or digit), or "" if impossible
+func journalFieldName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+
+	name = strings.TrimLeft(name, "_0123456789")
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
@@ -0,0 +1,134 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournaldNativeFields(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	notifier, err := NewJournaldNotifier(JournaldConfig{
+		SocketPath: socketPath,
+		Identifier: "billing",
+		Fields:     map[string]string{"service": "api"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	if notifier.Name() != "journald" {
+		t.Errorf("Expected name 'journald', got '%s'", notifier.Name())
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Title:    "Payment failed",
+		Text:     "card declined\nretrying",
+		Priority: PriorityLow,
+		Metadata: map[string]interface{}{"order-id": 42},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Failed to read datagram: %v", err)
+	}
+	entry := buf[:n]
+
+	for _, want := range []string{"PRIORITY=6\n", "SYSLOG_IDENTIFIER=billing\n", "SERVICE=api\n", "ORDER_ID=42\n", "NOTIFY_TITLE=Payment failed\n"} {
+		if !bytes.Contains(entry, []byte(want)) {
+			t.Errorf("Expected entry to contain %q, got %q", want, entry)
+		}
+	}
+
+	// Multi-line values use the binary length-prefixed encoding
+	message := "Payment failed: card declined\nretrying"
+	length := make([]byte, 8)
+	binary.LittleEndian.PutUint64(length, uint64(len(message)))
+	want := append(append([]byte("MESSAGE\n"), length...), message+"\n"...)
+	if !bytes.Contains(entry, want) {
+		t.Errorf("Expected binary MESSAGE field, got %q", entry)
+	}
+}
+
+func TestJournaldReservedFields(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "journal.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socketPath, Net: "unixgram"})
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := NewJournaldNotifier(JournaldConfig{SocketPath: socketPath, Fields: map[string]string{"message": "x"}}); err == nil {
+		t.Error("Expected error for a reserved extra field")
+	}
+
+	notifier, err := NewJournaldNotifier(JournaldConfig{SocketPath: socketPath, Identifier: "billing"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Text:     "disk full",
+		Priority: PriorityHigh,
+		Metadata: map[string]interface{}{
+			"message":           "spoofed",
+			"priority":          "urgent",
+			"syslog_identifier": "other",
+			"notify_title":      "spoofed",
+			"_pid":              1,
+			"host":              "web-1",
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	buf := make([]byte, 4096)
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatalf("Failed to read datagram: %v", err)
+	}
+	entry := buf[:n]
+
+	for _, want := range []string{"MESSAGE=disk full\n", "PRIORITY=2\n", "SYSLOG_IDENTIFIER=billing\n", "HOST=web-1\n"} {
+		if !bytes.Contains(entry, []byte(want)) {
+			t.Errorf("Expected entry to contain %q, got %q", want, entry)
+		}
+	}
+	for _, unwanted := range []string{"spoofed", "urgent", "other", "PID="} {
+		if bytes.Contains(entry, []byte(unwanted)) {
+			t.Errorf("Expected entry not to contain %q, got %q", unwanted, entry)
+		}
+	}
+}
+
+func TestJournalFieldName(t *testing.T) {
+	tests := map[string]string{
+		"thread_key": "THREAD_KEY",
+		"order-id":   "ORDER_ID",
+		"_private":   "PRIVATE",
+		"1st":        "ST",
+		"":           "",
+	}
+
+	for input, expected := range tests {
+		if got := journalFieldName(input); got != expected {
+			t.Errorf("journalFieldName(%q): expected %q, got %q", input, expected, got)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/mail"
+	"net/textproto"
+	"net/url"
+	"strings"
+)
+
+// MailgunNotifier sends email notifications through the Mailgun messages API
+type MailgunNotifier struct {
+	name      string
+	apiKey    string
+	domain    string
+	from      string
+	fromName  string
+	defaultTo []string
+	baseURL   string
+	client    *http.Client
+}
+
+// MailgunConfig holds configuration for Mailgun notifications
+type MailgunConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "mailgun")
+	Name string
+
+	// APIKey is the Mailgun private API key
+	APIKey string
+
+	// Domain is the sending domain (e.g., mg.example.com)
+	Domain string
+
+	// From is the sender address
+	From string
+
+	// FromName is the sender display name (optional)
+	FromName string
+
+	// DefaultTo are the recipients used when Message.Channel is empty (optional)
+	DefaultTo []string
+
+	// BaseURL overrides the API base URL (optional, defaults to https://api.mailgun.net;
+	// use https://api.eu.mailgun.net for EU domains)
+	BaseURL string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type mailgunResponse struct {
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+// NewMailgunNotifier creates a new Mailgun notifier
+func NewMailgunNotifier(config MailgunConfig) (*MailgunNotifier, error) {
+	if config.APIKey == "" {
+		return nil, &NotificationError{
+			Provider: "mailgun",
+			Message:  "API key is required",
+		}
+	}
+
+	if config.Domain == "" {
+		return nil, &NotificationError{
+			Provider: "mailgun",
+			Message:  "domain is required",
+		}
+	}
+
+	if _, err := mail.ParseAddress(config.From); err != nil {
+		return nil, &NotificationError{
+			Provider: "mailgun",
+			Message:  "a valid from address is required",
+			Err:      err,
+		}
+	}
+
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.mailgun.net"
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "mailgun"
+	}
+
+	return &MailgunNotifier{
+		name:      name,
+		apiKey:    config.APIKey,
+		domain:    config.Domain,
+		from:      config.From,
+		fromName:  config.FromName,
+		defaultTo: config.DefaultTo,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		client:    newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (m *MailgunNotifier) Name() string {
+	return m.name
+}
+
+// Send sends a simple text email to the default recipients
+func (m *MailgunNotifier) Send(ctx context.Context, message string) error {
+	return m.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends an email.
+// Channel is a comma-separated list of recipients, Title is the subject and the
+// message is sent as both plain text and HTML. Metadata keys "cc", "bcc",
+// "reply_to" and "email_files" add recipients and attachments.
+func (m *MailgunNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	_, err := m.Post(ctx, msg)
+	return err
+}
+
+// Post sends an email and returns the Mailgun message ID
+func (m *MailgunNotifier) Post(ctx context.Context, msg *Message) (string, error) {
+	email, err := renderEmail("mailgun", msg, m.defaultTo)
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("from", emailAddress(m.fromName, m.from))
+	form["to"] = email.To
+	if len(email.Cc) > 0 {
+		form["cc"] = email.Cc
+	}
+	if len(email.Bcc) > 0 {
+		form["bcc"] = email.Bcc
+	}
+	form.Set("subject", email.Subject)
+	form.Set("text", email.Text)
+	form.Set("html", email.HTML)
+	if email.ReplyTo != "" {
+		form.Set("h:Reply-To", email.ReplyTo)
+	}
+	for name, value := range email.Headers {
+		form.Set("h:"+name, value)
+	}
+
+	return m.send(ctx, form, email.Files)
+}
+
+// SendRichMessage sends raw messages API parameters.
+// blocks must be a map[string]string (e.g., "template" and "h:X-Mailgun-Variables");
+// "from" and "to" are filled in from the configuration and channel when missing.
+func (m *MailgunNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	params, ok := blocks.(map[string]string)
+	if !ok {
+		return &NotificationError{
+			Provider: "mailgun",
+			Message:  "blocks must be of type map[string]string",
+		}
+	}
+
+	form := url.Values{}
+	for key, value := range params {
+		form.Set(key, value)
+	}
+
+	if form.Get("from") == "" {
+		form.Set("from", emailAddress(m.fromName, m.from))
+	}
+	if form.Get("to") == "" {
+		to := m.defaultTo
+		if channel != "" {
+			to = splitList(channel)
+		}
+		if len(to) == 0 {
+			return &NotificationError{
+				Provider: "mailgun",
+				Message:  "at least one recipient is required",
+			}
+		}
+		form["to"] = to
+	}
+
+	_, err := m.send(ctx, form, nil)
+	return err
+}
+
+// send posts form and files as multipart/form-data and returns the message ID
+func (m *MailgunNotifier) send(ctx context.Context, form url.Values, files []EmailFile) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	for key, values := range form {
+		for _, value := range values {
+			if err := writer.WriteField(key, value); err != nil {
+				return "", &NotificationError{Provider: "mailgun", Message: "failed to build request", Err: err}
+			}
+		}
+	}
+
+	for _, file := range files {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="attachment"; filename=%q`, file.Filename))
+		header.Set("Content-Type", file.ContentType)
+
+		part, err := writer.CreatePart(header)
+		if err != nil {
+			return "", &NotificationError{Provider: "mailgun", Message: "failed to build request", Err: err}
+		}
+		if _, err := part.Write(file.Content); err != nil {
+			return "", &NotificationError{Provider: "mailgun", Message: "failed to build request", Err: err}
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		return "", &NotificationError{Provider: "mailgun", Message: "failed to build request", Err: err}
+	}
+
+	endpoint := fmt.Sprintf("%s/v3/%s/messages", m.baseURL, url.PathEscape(m.domain))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, &body)
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "mailgun",
+			Message:  "failed to create request",
+			Err:      err,
+		}
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.SetBasicAuth("api", m.apiKey)
+
+	status, respBody, err := doRequest(m.client, "mailgun", req)
+	if err != nil {
+		return "", err
+	}
+
+	var result mailgunResponse
+	_ = json.Unmarshal(respBody, &result)
+
+	if status < 200 || status >= 300 {
+		message := result.Message
+		if message == "" {
+			message = string(respBody)
+		}
+		return "", &NotificationError{
+			Provider: "mailgun",
+			Message:  fmt.Sprintf("API request failed with status %d: %s", status, message),
+		}
+	}
+
+	return result.ID, nil
+}
@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewMailgunNotifier(t *testing.T) {
+	_, err := NewMailgunNotifier(MailgunConfig{APIKey: "key", From: "alerts@example.com"})
+	if err == nil {
+		t.Error("Expected error when domain is missing")
+	}
+
+	notifier, err := NewMailgunNotifier(MailgunConfig{APIKey: "key", Domain: "mg.example.com", From: "alerts@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "mailgun" {
+		t.Errorf("Expected name 'mailgun', got '%s'", notifier.Name())
+	}
+}
+
+func TestMailgunPost(t *testing.T) {
+	var fields map[string][]string
+	var attachment string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/mg.example.com/messages" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "api" || pass != "key" {
+			t.Errorf("Unexpected basic auth %s:%s", user, pass)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("Failed to parse multipart form: %v", err)
+			return
+		}
+		fields = r.MultipartForm.Value
+
+		if files := r.MultipartForm.File["attachment"]; len(files) == 1 {
+			file, _ := files[0].Open()
+			data, _ := io.ReadAll(file)
+			attachment = files[0].Filename + ":" + string(data)
+		}
+
+		_, _ = w.Write([]byte(`{"id":"<mg-1@mg.example.com>","message":"Queued. Thank you."}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewMailgunNotifier(MailgunConfig{
+		APIKey:   "key",
+		Domain:   "mg.example.com",
+		From:     "alerts@example.com",
+		FromName: "Alerts",
+		BaseURL:  server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	messageID, err := notifier.Post(context.Background(), &Message{
+		Title:    "Nightly report",
+		Text:     "Report attached",
+		Priority: PriorityLow,
+		Channel:  "a@example.com,b@example.com",
+		Metadata: map[string]interface{}{
+			"reply_to":    "team@example.com",
+			"email_files": EmailFile{Filename: "report.txt", Content: []byte("ok")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	if messageID != "<mg-1@mg.example.com>" {
+		t.Errorf("Unexpected message ID %s", messageID)
+	}
+
+	if fields["from"][0] != `"Alerts" <alerts@example.com>` {
+		t.Errorf("Unexpected from: %v", fields["from"])
+	}
+	if len(fields["to"]) != 2 {
+		t.Errorf("Expected 2 recipients, got %v", fields["to"])
+	}
+	if fields["subject"][0] != "Nightly report" || fields["h:Reply-To"][0] != "team@example.com" || fields["h:X-Priority"][0] != "5" {
+		t.Errorf("Unexpected fields: %v", fields)
+	}
+	if len(fields["html"]) != 1 || len(fields["text"]) != 1 {
+		t.Errorf("Expected text and HTML bodies, got %v", fields)
+	}
+	if attachment != "report.txt:ok" {
+		t.Errorf("Unexpected attachment %q", attachment)
+	}
+}
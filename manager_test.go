@@ -198,3 +198,37 @@ func TestManagerSendWithOptions(t *testing.T) {
 		t.Error("Expected SendWithOptions to be called")
 	}
 }
+
+func TestManagerMultipleInstances(t *testing.T) {
+	manager := NewManager()
+
+	ops, err := NewSlackNotifier(&SlackConfig{Name: "slack-ops", Token: "xoxb-ops"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	eng, err := NewSlackNotifier(&SlackConfig{Name: "slack-eng", Token: "xoxb-eng"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	telegram, err := NewTelegramNotifier(TelegramConfig{BotToken: "token", ChatID: "1"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	alerts, err := NewTelegramNotifier(TelegramConfig{Name: "telegram-alerts", BotToken: "token", ChatID: "2"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	for _, notifier := range []Notifier{ops, eng, telegram, alerts} {
+		if err := manager.Register(notifier); err != nil {
+			t.Fatalf("Failed to register %s: %v", notifier.Name(), err)
+		}
+	}
+
+	if got, exists := manager.Get("slack-eng"); !exists || got != eng {
+		t.Error("Expected the slack-eng instance")
+	}
+	if got, exists := manager.Get("telegram"); !exists || got != telegram {
+		t.Error("Expected the Telegram notifier under its default name")
+	}
+}
@@ -0,0 +1,354 @@
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// MatrixNotifier sends notifications to Matrix rooms via the client-server API
+type MatrixNotifier struct {
+	name          string
+	homeserverURL string
+	accessToken   string
+	defaultRoom   string
+	msgType       string
+	client        *http.Client
+
+	// roomIDs caches resolved room aliases
+	roomIDs map[string]string
+	mu      sync.RWMutex
+}
+
+// MatrixConfig holds configuration for Matrix notifications
+type MatrixConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "matrix")
+	Name string
+
+	// HomeserverURL is the base URL of the homeserver (e.g., https://matrix.example.org)
+	HomeserverURL string
+
+	// AccessToken is the access token of the bot user
+	AccessToken string
+
+	// DefaultRoom is the default room ID (!room:server) or alias (#room:server)
+	DefaultRoom string
+
+	// MsgType is the message type to send (optional, defaults to m.text; bots often use m.notice)
+	MsgType string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type matrixEventResponse struct {
+	EventID string `json:"event_id"`
+}
+
+type matrixRoomAliasResponse struct {
+	RoomID string `json:"room_id"`
+}
+
+// NewMatrixNotifier creates a new Matrix notifier
+func NewMatrixNotifier(config MatrixConfig) (*MatrixNotifier, error) {
+	if config.HomeserverURL == "" {
+		return nil, &NotificationError{
+			Provider: "matrix",
+			Message:  "homeserver URL is required",
+		}
+	}
+
+	if config.AccessToken == "" {
+		return nil, &NotificationError{
+			Provider: "matrix",
+			Message:  "access token is required",
+		}
+	}
+
+	msgType := config.MsgType
+	if msgType == "" {
+		msgType = "m.text"
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "matrix"
+	}
+
+	return &MatrixNotifier{
+		name:          name,
+		homeserverURL: strings.TrimRight(config.HomeserverURL, "/"),
+		accessToken:   config.AccessToken,
+		defaultRoom:   config.DefaultRoom,
+		msgType:       msgType,
+		client:        newHTTPClient(config.HTTPClient),
+		roomIDs:       make(map[string]string),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (m *MatrixNotifier) Name() string {
+	return m.name
+}
+
+// Send sends a simple text message
+func (m *MatrixNotifier) Send(ctx context.Context, message string) error {
+	return m.SendWithOptions(ctx, &Message{
+		Text:    message,
+		Channel: m.defaultRoom,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Metadata["txn_id"] sets the transaction ID (reuse it when retrying to avoid duplicates),
+// Metadata["reply_to"] replies to an event and Metadata["thread_id"] posts in a thread.
+func (m *MatrixNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	_, err := m.Post(ctx, msg)
+	return err
+}
+
+// Post sends a message and returns the event ID of the created event
+func (m *MatrixNotifier) Post(ctx context.Context, msg *Message) (string, error) {
+	if msg.Text == "" && msg.Title == "" {
+		return "", &NotificationError{
+			Provider: "matrix",
+			Message:  "message text is required",
+		}
+	}
+
+	content := map[string]interface{}{
+		"msgtype":        m.msgType,
+		"body":           matrixPlainBody(msg),
+		"format":         "org.matrix.custom.html",
+		"formatted_body": matrixHTMLBody(msg),
+	}
+
+	if relatesTo := matrixRelation(metadataString(msg, "thread_id"), metadataString(msg, "reply_to")); relatesTo != nil {
+		content["m.relates_to"] = relatesTo
+	}
+
+	return m.sendEvent(ctx, msg.Channel, metadataString(msg, "txn_id"), content)
+}
+
+// SendRichMessage sends raw m.room.message event content.
+// blocks must be a map[string]interface{} containing at least "msgtype" and "body".
+func (m *MatrixNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	content, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "matrix",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	if _, ok := content["body"]; !ok {
+		return &NotificationError{
+			Provider: "matrix",
+			Message:  "event content must contain a body",
+		}
+	}
+
+	if _, ok := content["msgtype"]; !ok {
+		content["msgtype"] = m.msgType
+	}
+
+	_, err := m.sendEvent(ctx, channel, "", content)
+	return err
+}
+
+// ResolveRoom returns the room ID for a room ID or alias, caching alias lookups
+func (m *MatrixNotifier) ResolveRoom(ctx context.Context, room string) (string, error) {
+	if room == "" {
+		return "", &NotificationError{
+			Provider: "matrix",
+			Message:  "room is required",
+		}
+	}
+
+	if !strings.HasPrefix(room, "#") {
+		return room, nil
+	}
+
+	m.mu.RLock()
+	roomID, ok := m.roomIDs[room]
+	m.mu.RUnlock()
+	if ok {
+		return roomID, nil
+	}
+
+	var result matrixRoomAliasResponse
+	endpoint := m.homeserverURL + "/_matrix/client/v3/directory/room/" + url.PathEscape(room)
+	if err := sendJSON(ctx, m.client, "matrix", http.MethodGet, endpoint, m.authHeader(), nil, &result); err != nil {
+		return "", err
+	}
+
+	if result.RoomID == "" {
+		return "", &NotificationError{
+			Provider: "matrix",
+			Message:  fmt.Sprintf("room alias %s did not resolve to a room ID", room),
+		}
+	}
+
+	m.mu.Lock()
+	m.roomIDs[room] = result.RoomID
+	m.mu.Unlock()
+
+	return result.RoomID, nil
+}
+
+// sendEvent sends an m.room.message event to room using txnID (generated if empty)
+func (m *MatrixNotifier) sendEvent(ctx context.Context, room, txnID string, content map[string]interface{}) (string, error) {
+	if room == "" {
+		room = m.defaultRoom
+	}
+
+	roomID, err := m.ResolveRoom(ctx, room)
+	if err != nil {
+		return "", err
+	}
+
+	if txnID == "" {
+		txnID, err = newMatrixTxnID()
+		if err != nil {
+			return "", &NotificationError{
+				Provider: "matrix",
+				Message:  "failed to generate transaction ID",
+				Err:      err,
+			}
+		}
+	}
+
+	endpoint := fmt.Sprintf("%s/_matrix/client/v3/rooms/%s/send/m.room.message/%s",
+		m.homeserverURL, url.PathEscape(roomID), url.PathEscape(txnID))
+
+	var result matrixEventResponse
+	if err := sendJSON(ctx, m.client, "matrix", http.MethodPut, endpoint, m.authHeader(), content, &result); err != nil {
+		return "", err
+	}
+
+	return result.EventID, nil
+}
+
+// authHeader returns the Authorization header for client-server API requests
+func (m *MatrixNotifier) authHeader() http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+m.accessToken)
+	return header
+}
+
+// newMatrixTxnID returns a random transaction ID
+func newMatrixTxnID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return "notify-" + hex.EncodeToString(b), nil
+}
+
+// matrixRelation builds the m.relates_to content for threads and replies
+func matrixRelation(threadID, replyTo string) map[string]interface{} {
+	switch {
+	case threadID != "":
+		inReplyTo := replyTo
+		if inReplyTo == "" {
+			inReplyTo = threadID
+		}
+		return map[string]interface{}{
+			"rel_type":        "m.thread",
+			"event_id":        threadID,
+			"is_falling_back": replyTo == "",
+			"m.in_reply_to":   map[string]interface{}{"event_id": inReplyTo},
+		}
+	case replyTo != "":
+		return map[string]interface{}{
+			"m.in_reply_to": map[string]interface{}{"event_id": replyTo},
+		}
+	default:
+		return nil
+	}
+}
+
+// matrixPlainBody renders the message as plain text
+func matrixPlainBody(msg *Message) string {
+	var sb strings.Builder
+
+	if msg.Title != "" {
+		sb.WriteString(msg.Title)
+		sb.WriteString("\n\n")
+	}
+	sb.WriteString(msg.Text)
+
+	for _, att := range msg.Attachments {
+		sb.WriteString("\n")
+		if att.Title != "" {
+			sb.WriteString("\n")
+			sb.WriteString(att.Title)
+		}
+		if att.Text != "" {
+			sb.WriteString("\n")
+			sb.WriteString(att.Text)
+		}
+		for _, field := range att.Fields {
+			fmt.Fprintf(&sb, "\n%s: %s", field.Title, field.Value)
+		}
+		if att.ImageURL != "" {
+			sb.WriteString("\n")
+			sb.WriteString(att.ImageURL)
+		}
+		if att.Footer != "" {
+			sb.WriteString("\n")
+			sb.WriteString(att.Footer)
+		}
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// matrixHTMLBody renders the message as HTML for formatted_body
+func matrixHTMLBody(msg *Message) string {
+	var sb strings.Builder
+
+	if msg.Title != "" {
+		fmt.Fprintf(&sb, "<h4>%s</h4>", html.EscapeString(msg.Title))
+	}
+	if msg.Text != "" {
+		fmt.Fprintf(&sb, "<p>%s</p>", matrixEscapeLines(msg.Text))
+	}
+
+	for _, att := range msg.Attachments {
+		sb.WriteString("<blockquote>")
+		if att.Title != "" {
+			fmt.Fprintf(&sb, "<strong>%s</strong><br>", html.EscapeString(att.Title))
+		}
+		if att.Text != "" {
+			fmt.Fprintf(&sb, "%s<br>", matrixEscapeLines(att.Text))
+		}
+		if len(att.Fields) > 0 {
+			sb.WriteString("<ul>")
+			for _, field := range att.Fields {
+				fmt.Fprintf(&sb, "<li><strong>%s:</strong> %s</li>", html.EscapeString(field.Title), html.EscapeString(field.Value))
+			}
+			sb.WriteString("</ul>")
+		}
+		if att.ImageURL != "" {
+			fmt.Fprintf(&sb, "<a href=\"%s\">%s</a><br>", html.EscapeString(att.ImageURL), html.EscapeString(att.ImageURL))
+		}
+		if att.Footer != "" {
+			fmt.Fprintf(&sb, "<em>%s</em>", html.EscapeString(att.Footer))
+		}
+		sb.WriteString("</blockquote>")
+	}
+
+	return sb.String()
+}
+
+// matrixEscapeLines escapes text for HTML and converts newlines to line breaks
+func matrixEscapeLines(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+}
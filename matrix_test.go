@@ -0,0 +1,103 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewMatrixNotifier(t *testing.T) {
+	_, err := NewMatrixNotifier(MatrixConfig{HomeserverURL: "https://matrix.example.org"})
+	if err == nil {
+		t.Error("Expected error when access token is missing")
+	}
+
+	notifier, err := NewMatrixNotifier(MatrixConfig{HomeserverURL: "https://matrix.example.org", AccessToken: "token"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "matrix" {
+		t.Errorf("Expected name 'matrix', got '%s'", notifier.Name())
+	}
+}
+
+func TestMatrixSendWithAliasAndThread(t *testing.T) {
+	aliasLookups := 0
+	var sendPath string
+	var content map[string]interface{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Unexpected Authorization header: %s", r.Header.Get("Authorization"))
+		}
+
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/_matrix/client/v3/directory/room/"):
+			aliasLookups++
+			_, _ = w.Write([]byte(`{"room_id":"!abc:example.org"}`))
+		case r.Method == http.MethodPut:
+			sendPath = r.URL.Path
+			if err := json.NewDecoder(r.Body).Decode(&content); err != nil {
+				t.Errorf("Failed to decode content: %v", err)
+			}
+			_, _ = w.Write([]byte(`{"event_id":"$event"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewMatrixNotifier(MatrixConfig{
+		HomeserverURL: server.URL,
+		AccessToken:   "token",
+		DefaultRoom:   "#alerts:example.org",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	msg := &Message{
+		Title: "Deploy <prod>",
+		Text:  "line1\nline2",
+		Metadata: map[string]interface{}{
+			"txn_id":    "txn-1",
+			"thread_id": "$root",
+		},
+	}
+
+	eventID, err := notifier.Post(context.Background(), msg)
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	if eventID != "$event" {
+		t.Errorf("Expected event ID '$event', got '%s'", eventID)
+	}
+
+	if sendPath != "/_matrix/client/v3/rooms/!abc:example.org/send/m.room.message/txn-1" {
+		t.Errorf("Unexpected send path: %s", sendPath)
+	}
+
+	formatted := content["formatted_body"].(string)
+	if !strings.Contains(formatted, "<h4>Deploy &lt;prod&gt;</h4>") || !strings.Contains(formatted, "line1<br>line2") {
+		t.Errorf("Unexpected formatted body: %s", formatted)
+	}
+
+	relatesTo := content["m.relates_to"].(map[string]interface{})
+	if relatesTo["rel_type"] != "m.thread" || relatesTo["event_id"] != "$root" {
+		t.Errorf("Unexpected relation: %v", relatesTo)
+	}
+
+	// Second send must use the cached alias
+	if _, err := notifier.Post(context.Background(), msg); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	if aliasLookups != 1 {
+		t.Errorf("Expected 1 alias lookup, got %d", aliasLookups)
+	}
+}
@@ -0,0 +1,271 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// MattermostNotifier sends notifications to Mattermost via incoming webhooks or the REST API
+type MattermostNotifier struct {
+	name           string
+	webhookURL     string
+	serverURL      string
+	token          string
+	defaultChannel string
+	username       string
+	iconURL        string
+	iconEmoji      string
+	client         *http.Client
+}
+
+// MattermostConfig holds configuration for Mattermost notifications
+type MattermostConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "mattermost")
+	Name string
+
+	// WebhookURL for incoming webhooks (alternative to ServerURL and Token)
+	WebhookURL string
+
+	// ServerURL is the Mattermost server base URL (e.g., https://chat.example.com), used with Token
+	ServerURL string
+
+	// Token is a bot or personal access token used with the REST API
+	Token string
+
+	// DefaultChannel is the default channel: a channel name override in webhook mode,
+	// or a channel ID in REST API mode
+	DefaultChannel string
+
+	// Username overrides the displayed username (optional)
+	Username string
+
+	// IconURL overrides the displayed profile picture (optional)
+	IconURL string
+
+	// IconEmoji overrides the displayed profile picture with an emoji (optional, webhook mode only)
+	IconEmoji string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+// slackStyleAttachment is the Slack-compatible attachment format accepted by
+// Mattermost and Rocket.Chat
+type slackStyleAttachment struct {
+	Fallback   string            `json:"fallback,omitempty"`
+	Color      string            `json:"color,omitempty"`
+	Title      string            `json:"title,omitempty"`
+	Text       string            `json:"text,omitempty"`
+	Fields     []slackStyleField `json:"fields,omitempty"`
+	ImageURL   string            `json:"image_url,omitempty"`
+	Footer     string            `json:"footer,omitempty"`
+	FooterIcon string            `json:"footer_icon,omitempty"`
+}
+
+type slackStyleField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+type mattermostPost struct {
+	ID string `json:"id"`
+}
+
+// NewMattermostNotifier creates a new Mattermost notifier
+func NewMattermostNotifier(config MattermostConfig) (*MattermostNotifier, error) {
+	if config.WebhookURL == "" && (config.ServerURL == "" || config.Token == "") {
+		return nil, &NotificationError{
+			Provider: "mattermost",
+			Message:  "either webhook URL or server URL and token are required",
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "mattermost"
+	}
+
+	return &MattermostNotifier{
+		name:           name,
+		webhookURL:     config.WebhookURL,
+		serverURL:      strings.TrimRight(config.ServerURL, "/"),
+		token:          config.Token,
+		defaultChannel: config.DefaultChannel,
+		username:       config.Username,
+		iconURL:        config.IconURL,
+		iconEmoji:      config.IconEmoji,
+		client:         newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (m *MattermostNotifier) Name() string {
+	return m.name
+}
+
+// Send sends a simple text message
+func (m *MattermostNotifier) Send(ctx context.Context, message string) error {
+	return m.SendWithOptions(ctx, &Message{
+		Text:    message,
+		Channel: m.defaultChannel,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Metadata["root_id"] replies in the thread of the given post (REST API mode only).
+func (m *MattermostNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	_, err := m.Post(ctx, msg)
+	return err
+}
+
+// Post sends a message and returns the ID of the created post.
+// The ID is only available in REST API mode and can be used as Metadata["root_id"]
+// to thread replies.
+func (m *MattermostNotifier) Post(ctx context.Context, msg *Message) (string, error) {
+	if msg.Text == "" && len(msg.Attachments) == 0 {
+		return "", &NotificationError{
+			Provider: "mattermost",
+			Message:  "message text is required",
+		}
+	}
+
+	text := msg.Text
+	if msg.Title != "" {
+		text = "#### " + msg.Title + "\n" + msg.Text
+	}
+
+	channel := msg.Channel
+	if channel == "" {
+		channel = m.defaultChannel
+	}
+
+	return m.post(ctx, channel, text, convertSlackStyleAttachments(msg.Attachments), metadataString(msg, "root_id"))
+}
+
+// SendRichMessage sends a message made only of attachments.
+// blocks must be of type []Attachment.
+func (m *MattermostNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	attachments, ok := blocks.([]Attachment)
+	if !ok {
+		return &NotificationError{
+			Provider: "mattermost",
+			Message:  "blocks must be of type []Attachment",
+		}
+	}
+
+	if channel == "" {
+		channel = m.defaultChannel
+	}
+
+	_, err := m.post(ctx, channel, "", convertSlackStyleAttachments(attachments), "")
+	return err
+}
+
+// post sends the message through the webhook or the REST API depending on configuration
+func (m *MattermostNotifier) post(ctx context.Context, channel, text string, attachments []slackStyleAttachment, rootID string) (string, error) {
+	if m.webhookURL != "" {
+		if rootID != "" {
+			return "", &NotificationError{
+				Provider: "mattermost",
+				Message:  "threaded replies require REST API mode",
+			}
+		}
+
+		payload := map[string]interface{}{
+			"text": text,
+		}
+		if channel != "" {
+			payload["channel"] = strings.TrimPrefix(channel, "#")
+		}
+		if m.username != "" {
+			payload["username"] = m.username
+		}
+		if m.iconURL != "" {
+			payload["icon_url"] = m.iconURL
+		}
+		if m.iconEmoji != "" {
+			payload["icon_emoji"] = m.iconEmoji
+		}
+		if len(attachments) > 0 {
+			payload["attachments"] = attachments
+		}
+
+		return "", sendJSON(ctx, m.client, "mattermost", http.MethodPost, m.webhookURL, nil, payload, nil)
+	}
+
+	if channel == "" {
+		return "", &NotificationError{
+			Provider: "mattermost",
+			Message:  "channel ID is required",
+		}
+	}
+
+	props := map[string]interface{}{}
+	if len(attachments) > 0 {
+		props["attachments"] = attachments
+	}
+	if m.username != "" {
+		props["override_username"] = m.username
+	}
+	if m.iconURL != "" {
+		props["override_icon_url"] = m.iconURL
+	}
+
+	payload := map[string]interface{}{
+		"channel_id": channel,
+		"message":    text,
+	}
+	if rootID != "" {
+		payload["root_id"] = rootID
+	}
+	if len(props) > 0 {
+		payload["props"] = props
+	}
+
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+m.token)
+
+	var result mattermostPost
+	if err := sendJSON(ctx, m.client, "mattermost", http.MethodPost, m.serverURL+"/api/v4/posts", header, payload, &result); err != nil {
+		return "", err
+	}
+
+	return result.ID, nil
+}
+
+// convertSlackStyleAttachments converts generic attachments to Slack-style attachments
+func convertSlackStyleAttachments(attachments []Attachment) []slackStyleAttachment {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	converted := make([]slackStyleAttachment, len(attachments))
+	for i, att := range attachments {
+		fallback := att.Title
+		if fallback == "" {
+			fallback = att.Text
+		}
+
+		converted[i] = slackStyleAttachment{
+			Fallback:   fallback,
+			Color:      att.Color,
+			Title:      att.Title,
+			Text:       att.Text,
+			ImageURL:   att.ImageURL,
+			Footer:     att.Footer,
+			FooterIcon: att.FooterIcon,
+		}
+
+		for _, field := range att.Fields {
+			converted[i].Fields = append(converted[i].Fields, slackStyleField{
+				Title: field.Title,
+				Value: field.Value,
+				Short: field.Short,
+			})
+		}
+	}
+
+	return converted
+}
@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewMattermostNotifier(t *testing.T) {
+	_, err := NewMattermostNotifier(MattermostConfig{ServerURL: "https://chat.example.com"})
+	if err == nil {
+		t.Error("Expected error when token is missing in API mode")
+	}
+
+	notifier, err := NewMattermostNotifier(MattermostConfig{WebhookURL: "https://chat.example.com/hooks/x"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "mattermost" {
+		t.Errorf("Expected name 'mattermost', got '%s'", notifier.Name())
+	}
+}
+
+func TestMattermostWebhook(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	notifier, err := NewMattermostNotifier(MattermostConfig{
+		WebhookURL: server.URL,
+		Username:   "notify-bot",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Text:    "Build failed",
+		Channel: "#builds",
+		Attachments: []Attachment{
+			{Title: "Job", Color: "danger", Fields: []Field{{Title: "Branch", Value: "main", Short: true}}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if body["channel"] != "builds" {
+		t.Errorf("Expected channel 'builds', got '%v'", body["channel"])
+	}
+
+	if body["username"] != "notify-bot" {
+		t.Errorf("Expected username 'notify-bot', got '%v'", body["username"])
+	}
+
+	attachments := body["attachments"].([]interface{})
+	fields := attachments[0].(map[string]interface{})["fields"].([]interface{})
+	if fields[0].(map[string]interface{})["value"] != "main" {
+		t.Errorf("Unexpected attachment fields: %v", fields)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Text:     "reply",
+		Metadata: map[string]interface{}{"root_id": "abc"},
+	})
+	if err == nil {
+		t.Error("Expected error for threaded reply in webhook mode")
+	}
+}
+
+func TestMattermostAPIThreadedReply(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/posts" {
+			t.Errorf("Expected path /api/v4/posts, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Unexpected Authorization header: %s", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"post-2"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewMattermostNotifier(MattermostConfig{
+		ServerURL:      server.URL,
+		Token:          "token",
+		DefaultChannel: "channel-id",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	id, err := notifier.Post(context.Background(), &Message{
+		Text:     "Still investigating",
+		Metadata: map[string]interface{}{"root_id": "post-1"},
+	})
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	if id != "post-2" {
+		t.Errorf("Expected post ID 'post-2', got '%s'", id)
+	}
+
+	if body["channel_id"] != "channel-id" || body["root_id"] != "post-1" {
+		t.Errorf("Unexpected post body: %v", body)
+	}
+}
+
+func TestRocketChatAPI(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Auth-Token") != "token" || r.Header.Get("X-User-Id") != "user" {
+			t.Error("Expected auth headers to be set")
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"success":true,"message":{"_id":"msg-1"}}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewRocketChatNotifier(RocketChatConfig{
+		ServerURL:      server.URL,
+		UserID:         "user",
+		AuthToken:      "token",
+		DefaultChannel: "#alerts",
+		Alias:          "notify",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	id, err := notifier.Post(context.Background(), &Message{
+		Text:     "Deploy done",
+		Metadata: map[string]interface{}{"tmid": "parent"},
+	})
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	if id != "msg-1" {
+		t.Errorf("Expected message ID 'msg-1', got '%s'", id)
+	}
+
+	if body["channel"] != "#alerts" || body["tmid"] != "parent" || body["alias"] != "notify" {
+		t.Errorf("Unexpected message body: %v", body)
+	}
+}
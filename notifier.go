@@ -23,40 +23,40 @@ type Notifier interface {
 // Message represents a notification message with options
 type Message struct {
 	// Text is the main message content
-	Text string
+	Text string `json:"text"`
 
 	// Title is an optional title for the message
-	Title string
+	Title string `json:"title,omitempty"`
 
 	// Priority defines the message priority (high, normal, low)
-	Priority string
+	Priority string `json:"priority,omitempty"`
 
 	// Channel defines the target channel/chat (provider-specific)
-	Channel string
+	Channel string `json:"channel,omitempty"`
 
 	// Attachments for rich messages (provider-specific)
-	Attachments []Attachment
+	Attachments []Attachment `json:"attachments,omitempty"`
 
 	// Metadata for additional provider-specific data
-	Metadata map[string]interface{}
+	Metadata map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // Attachment represents a message attachment
 type Attachment struct {
-	Title      string
-	Text       string
-	ImageURL   string
-	Color      string
-	Fields     []Field
-	Footer     string
-	FooterIcon string
+	Title      string  `json:"title,omitempty"`
+	Text       string  `json:"text,omitempty"`
+	ImageURL   string  `json:"image_url,omitempty"`
+	Color      string  `json:"color,omitempty"`
+	Fields     []Field `json:"fields,omitempty"`
+	Footer     string  `json:"footer,omitempty"`
+	FooterIcon string  `json:"footer_icon,omitempty"`
 }
 
 // Field represents a key-value field in an attachment
 type Field struct {
-	Title string
-	Value string
-	Short bool
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short,omitempty"`
 }
 
 // Priority constants
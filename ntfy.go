@@ -0,0 +1,239 @@
+package notify
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"strings"
+)
+
+// NtfyNotifier sends push notifications via an ntfy server
+type NtfyNotifier struct {
+	name      string
+	serverURL string
+	topic     string
+	token     string
+	username  string
+	password  string
+	client    *http.Client
+}
+
+// NtfyConfig holds configuration for ntfy notifications
+type NtfyConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "ntfy")
+	Name string
+
+	// ServerURL is the ntfy server URL (optional, defaults to https://ntfy.sh)
+	ServerURL string
+
+	// Topic is the default topic to publish to
+	Topic string
+
+	// Token is an access token for protected topics (optional)
+	Token string
+
+	// Username and Password for basic auth on protected topics (optional, alternative to Token)
+	Username string
+	Password string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+// NtfyAction is a user action button shown with the notification
+type NtfyAction struct {
+	// Action is the action type: view, http or broadcast
+	Action string `json:"action"`
+	Label  string `json:"label"`
+	URL    string `json:"url,omitempty"`
+	Method string `json:"method,omitempty"`
+	Body   string `json:"body,omitempty"`
+	Clear  bool   `json:"clear,omitempty"`
+}
+
+type ntfyMessage struct {
+	Topic    string       `json:"topic"`
+	Message  string       `json:"message,omitempty"`
+	Title    string       `json:"title,omitempty"`
+	Priority int          `json:"priority,omitempty"`
+	Tags     []string     `json:"tags,omitempty"`
+	Click    string       `json:"click,omitempty"`
+	Actions  []NtfyAction `json:"actions,omitempty"`
+	Attach   string       `json:"attach,omitempty"`
+	Icon     string       `json:"icon,omitempty"`
+	Markdown bool         `json:"markdown,omitempty"`
+}
+
+// NewNtfyNotifier creates a new ntfy notifier
+func NewNtfyNotifier(config NtfyConfig) (*NtfyNotifier, error) {
+	if config.Topic == "" {
+		return nil, &NotificationError{
+			Provider: "ntfy",
+			Message:  "topic is required",
+		}
+	}
+
+	serverURL := config.ServerURL
+	if serverURL == "" {
+		serverURL = "https://ntfy.sh"
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "ntfy"
+	}
+
+	return &NtfyNotifier{
+		name:      name,
+		serverURL: strings.TrimRight(serverURL, "/"),
+		topic:     config.Topic,
+		token:     config.Token,
+		username:  config.Username,
+		password:  config.Password,
+		client:    newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (n *NtfyNotifier) Name() string {
+	return n.name
+}
+
+// Send sends a simple text message
+func (n *NtfyNotifier) Send(ctx context.Context, message string) error {
+	return n.SendWithOptions(ctx, &Message{
+		Text:    message,
+		Channel: n.topic,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Channel selects the topic; Metadata keys "priority" (1–5), "tags", "click",
+// "actions" ([]NtfyAction), "attach", "icon" and "markdown" map to ntfy fields.
+func (n *NtfyNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if msg.Text == "" {
+		return &NotificationError{
+			Provider: "ntfy",
+			Message:  "message text is required",
+		}
+	}
+
+	topic := msg.Channel
+	if topic == "" {
+		topic = n.topic
+	}
+
+	priority, ok := metadataInt(msg, "priority")
+	if !ok || priority < 1 || priority > 5 {
+		priority = ntfyPriority(msg.Priority)
+	}
+
+	payload := &ntfyMessage{
+		Topic:    topic,
+		Message:  msg.Text,
+		Title:    msg.Title,
+		Priority: priority,
+		Tags:     metadataStrings(msg, "tags"),
+		Click:    metadataString(msg, "click"),
+		Attach:   metadataString(msg, "attach"),
+		Icon:     metadataString(msg, "icon"),
+	}
+
+	if actions, ok := msg.Metadata["actions"].([]NtfyAction); ok {
+		payload.Actions = actions
+	}
+
+	if markdown, ok := msg.Metadata["markdown"].(bool); ok {
+		payload.Markdown = markdown
+	}
+
+	// ntfy has no attachment model, so append attachment fields to the message body
+	if len(msg.Attachments) > 0 {
+		payload.Message = msg.Text + "\n" + plainTextAttachments(msg.Attachments)
+	}
+
+	if payload.Attach == "" {
+		for _, att := range msg.Attachments {
+			if att.ImageURL != "" {
+				payload.Attach = att.ImageURL
+				break
+			}
+		}
+	}
+
+	return n.publish(ctx, payload)
+}
+
+// SendRichMessage publishes a raw JSON message.
+// blocks must be a map[string]interface{} following the ntfy JSON publishing schema;
+// channel, if set, overrides the topic.
+func (n *NtfyNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	payload, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "ntfy",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	if channel != "" {
+		payload["topic"] = channel
+	} else if _, ok := payload["topic"]; !ok {
+		payload["topic"] = n.topic
+	}
+
+	return n.publish(ctx, payload)
+}
+
+// publish posts a JSON message to the server root
+func (n *NtfyNotifier) publish(ctx context.Context, payload interface{}) error {
+	header := http.Header{}
+	if n.token != "" {
+		header.Set("Authorization", "Bearer "+n.token)
+	} else if n.username != "" {
+		credentials := base64.StdEncoding.EncodeToString([]byte(n.username + ":" + n.password))
+		header.Set("Authorization", "Basic "+credentials)
+	}
+
+	return sendJSON(ctx, n.client, "ntfy", http.MethodPost, n.serverURL+"/", header, payload, nil)
+}
+
+// ntfyPriority maps a message priority to an ntfy priority (1–5)
+func ntfyPriority(priority string) int {
+	switch priority {
+	case PriorityHigh:
+		return 4
+	case PriorityLow:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// plainTextAttachments renders attachments as plain text lines for providers without rich formatting
+func plainTextAttachments(attachments []Attachment) string {
+	var sb strings.Builder
+
+	for _, att := range attachments {
+		if att.Title != "" {
+			sb.WriteString("\n")
+			sb.WriteString(att.Title)
+		}
+		if att.Text != "" {
+			sb.WriteString("\n")
+			sb.WriteString(att.Text)
+		}
+		for _, field := range att.Fields {
+			sb.WriteString("\n")
+			sb.WriteString(field.Title)
+			sb.WriteString(": ")
+			sb.WriteString(field.Value)
+		}
+		if att.Footer != "" {
+			sb.WriteString("\n")
+			sb.WriteString(att.Footer)
+		}
+	}
+
+	return strings.TrimPrefix(sb.String(), "\n")
+}
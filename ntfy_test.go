@@ -0,0 +1,70 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewNtfyNotifier(t *testing.T) {
+	_, err := NewNtfyNotifier(NtfyConfig{})
+	if err == nil {
+		t.Error("Expected error when topic is missing")
+	}
+
+	notifier, err := NewNtfyNotifier(NtfyConfig{Topic: "alerts"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "ntfy" {
+		t.Errorf("Expected name 'ntfy', got '%s'", notifier.Name())
+	}
+}
+
+func TestNtfySendWithOptions(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tk_test" {
+			t.Errorf("Unexpected Authorization header: %s", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id":"abc","event":"message"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewNtfyNotifier(NtfyConfig{ServerURL: server.URL, Topic: "alerts", Token: "tk_test"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Title:    "Backup failed",
+		Text:     "Nightly backup did not complete",
+		Priority: PriorityHigh,
+		Metadata: map[string]interface{}{
+			"tags":    []string{"warning", "floppy_disk"},
+			"click":   "https://example.com/backups",
+			"actions": []NtfyAction{{Action: "view", Label: "Open", URL: "https://example.com"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if body["topic"] != "alerts" || body["title"] != "Backup failed" {
+		t.Errorf("Unexpected body: %v", body)
+	}
+
+	if body["priority"] != float64(4) {
+		t.Errorf("Expected priority 4, got %v", body["priority"])
+	}
+
+	if len(body["tags"].([]interface{})) != 2 || len(body["actions"].([]interface{})) != 1 {
+		t.Errorf("Expected tags and actions to be set: %v", body)
+	}
+}
@@ -0,0 +1,299 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// opsgenieReservedMetadata lists metadata keys that are mapped to alert fields rather than details
+var opsgenieReservedMetadata = map[string]bool{
+	"alias":      true,
+	"tags":       true,
+	"priority":   true,
+	"entity":     true,
+	"source":     true,
+	"responders": true,
+	"action":     true,
+	"note":       true,
+	"user":       true,
+}
+
+// OpsgenieNotifier creates and manages alerts via the Opsgenie Alert API
+type OpsgenieNotifier struct {
+	name       string
+	apiKey     string
+	baseURL    string
+	source     string
+	user       string
+	responders []OpsgenieResponder
+	tags       []string
+	client     *http.Client
+}
+
+// OpsgenieConfig holds configuration for Opsgenie notifications
+type OpsgenieConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "opsgenie")
+	Name string
+
+	// APIKey is the Opsgenie API integration key
+	APIKey string
+
+	// BaseURL overrides the API base URL (optional, e.g., https://api.eu.opsgenie.com or a test server)
+	BaseURL string
+
+	// Source is the default alert source (optional)
+	Source string
+
+	// User is the default display name of the request owner (optional)
+	User string
+
+	// Responders are notified for every alert created by this notifier (optional)
+	Responders []OpsgenieResponder
+
+	// Tags are added to every alert created by this notifier (optional)
+	Tags []string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+// OpsgenieResponder identifies a team, user, escalation or schedule to notify.
+// Either ID or Name (Username for users) must be set.
+type OpsgenieResponder struct {
+	Type     string `json:"type"`
+	ID       string `json:"id,omitempty"`
+	Name     string `json:"name,omitempty"`
+	Username string `json:"username,omitempty"`
+}
+
+type opsgenieAlert struct {
+	Message     string              `json:"message"`
+	Alias       string              `json:"alias,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Responders  []OpsgenieResponder `json:"responders,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Details     map[string]string   `json:"details,omitempty"`
+	Entity      string              `json:"entity,omitempty"`
+	Source      string              `json:"source,omitempty"`
+	Priority    string              `json:"priority,omitempty"`
+	User        string              `json:"user,omitempty"`
+}
+
+type opsgenieAction struct {
+	User   string `json:"user,omitempty"`
+	Source string `json:"source,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// NewOpsgenieNotifier creates a new Opsgenie notifier
+func NewOpsgenieNotifier(config OpsgenieConfig) (*OpsgenieNotifier, error) {
+	if config.APIKey == "" {
+		return nil, &NotificationError{
+			Provider: "opsgenie",
+			Message:  "API key is required",
+		}
+	}
+
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.opsgenie.com"
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "opsgenie"
+	}
+
+	return &OpsgenieNotifier{
+		name:       name,
+		apiKey:     config.APIKey,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		source:     config.Source,
+		user:       config.User,
+		responders: config.Responders,
+		tags:       config.Tags,
+		client:     newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (o *OpsgenieNotifier) Name() string {
+	return o.name
+}
+
+// Send creates an alert with the given message
+func (o *OpsgenieNotifier) Send(ctx context.Context, message string) error {
+	return o.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions creates an alert from msg.
+// Metadata["action"] set to "close" or "acknowledge" operates on the alert
+// identified by Metadata["alias"] instead.
+func (o *OpsgenieNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	switch action := metadataString(msg, "action"); action {
+	case "", "create":
+		return o.createAlert(ctx, msg)
+	case "close", "acknowledge":
+		note := metadataString(msg, "note")
+		if note == "" {
+			note = msg.Text
+		}
+		return o.alertAction(ctx, action, metadataString(msg, "alias"), note)
+	default:
+		return &NotificationError{
+			Provider: "opsgenie",
+			Message:  fmt.Sprintf("unsupported alert action: %s", action),
+		}
+	}
+}
+
+// SendRichMessage creates an alert from a raw request body.
+// blocks must be a map[string]interface{} following the Opsgenie create alert schema.
+func (o *OpsgenieNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	alert, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "opsgenie",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	if _, ok := alert["message"]; !ok {
+		return &NotificationError{
+			Provider: "opsgenie",
+			Message:  "alert message is required",
+		}
+	}
+
+	return o.request(ctx, "/v2/alerts", alert)
+}
+
+// Close closes the alert identified by alias
+func (o *OpsgenieNotifier) Close(ctx context.Context, alias, note string) error {
+	return o.alertAction(ctx, "close", alias, note)
+}
+
+// Acknowledge acknowledges the alert identified by alias
+func (o *OpsgenieNotifier) Acknowledge(ctx context.Context, alias, note string) error {
+	return o.alertAction(ctx, "acknowledge", alias, note)
+}
+
+// createAlert builds and posts a create alert request
+func (o *OpsgenieNotifier) createAlert(ctx context.Context, msg *Message) error {
+	message := msg.Title
+	description := msg.Text
+	if message == "" {
+		message = msg.Text
+		description = ""
+	}
+
+	if message == "" {
+		return &NotificationError{
+			Provider: "opsgenie",
+			Message:  "message text is required",
+		}
+	}
+
+	// Opsgenie limits the alert message to 130 characters
+	if utf8.RuneCountInString(message) > 130 {
+		if description == "" {
+			description = message
+		}
+		message = truncate(message, 130)
+	}
+
+	priority := metadataString(msg, "priority")
+	if priority == "" {
+		priority = opsgeniePriority(msg.Priority)
+	}
+
+	alert := &opsgenieAlert{
+		Message:     message,
+		Alias:       metadataString(msg, "alias"),
+		Description: description,
+		Responders:  o.responders,
+		Tags:        append(append([]string{}, o.tags...), metadataStrings(msg, "tags")...),
+		Details:     opsgenieDetails(msg),
+		Entity:      metadataString(msg, "entity"),
+		Source:      o.source,
+		Priority:    priority,
+		User:        o.user,
+	}
+
+	if source := metadataString(msg, "source"); source != "" {
+		alert.Source = source
+	}
+
+	if responders, ok := msg.Metadata["responders"].([]OpsgenieResponder); ok {
+		alert.Responders = append(append([]OpsgenieResponder{}, o.responders...), responders...)
+	}
+
+	return o.request(ctx, "/v2/alerts", alert)
+}
+
+// alertAction performs a close or acknowledge action on an alert identified by alias
+func (o *OpsgenieNotifier) alertAction(ctx context.Context, action, alias, note string) error {
+	if alias == "" {
+		return &NotificationError{
+			Provider: "opsgenie",
+			Message:  fmt.Sprintf("alias is required to %s an alert", action),
+		}
+	}
+
+	path := fmt.Sprintf("/v2/alerts/%s/%s?identifierType=alias", url.PathEscape(alias), action)
+
+	return o.request(ctx, path, &opsgenieAction{
+		User:   o.user,
+		Source: o.source,
+		Note:   note,
+	})
+}
+
+// request posts a JSON body to the Alert API
+func (o *OpsgenieNotifier) request(ctx context.Context, path string, payload interface{}) error {
+	header := http.Header{}
+	header.Set("Authorization", "GenieKey "+o.apiKey)
+
+	return sendJSON(ctx, o.client, "opsgenie", http.MethodPost, o.baseURL+path, header, payload, nil)
+}
+
+// opsgeniePriority maps a message priority to an Opsgenie priority (P1–P5)
+func opsgeniePriority(priority string) string {
+	switch priority {
+	case PriorityHigh:
+		return "P1"
+	case PriorityLow:
+		return "P5"
+	default:
+		return "P3"
+	}
+}
+
+// opsgenieDetails builds alert details from non-reserved metadata and attachment fields
+func opsgenieDetails(msg *Message) map[string]string {
+	details := make(map[string]string)
+
+	for key, value := range msg.Metadata {
+		if opsgenieReservedMetadata[key] {
+			continue
+		}
+		details[key] = fmt.Sprintf("%v", value)
+	}
+
+	for _, att := range msg.Attachments {
+		for _, field := range att.Fields {
+			details[field.Title] = field.Value
+		}
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+	return details
+}
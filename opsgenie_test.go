@@ -0,0 +1,148 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewOpsgenieNotifier(t *testing.T) {
+	_, err := NewOpsgenieNotifier(OpsgenieConfig{})
+	if err == nil {
+		t.Error("Expected error when API key is missing")
+	}
+
+	notifier, err := NewOpsgenieNotifier(OpsgenieConfig{APIKey: "key"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "opsgenie" {
+		t.Errorf("Expected name 'opsgenie', got '%s'", notifier.Name())
+	}
+}
+
+func TestOpsgenieCreateAlert(t *testing.T) {
+	var alert map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/alerts" {
+			t.Errorf("Expected path /v2/alerts, got %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "GenieKey key" {
+			t.Errorf("Unexpected Authorization header: %s", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&alert); err != nil {
+			t.Errorf("Failed to decode alert: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"result":"Request will be processed","requestId":"1"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewOpsgenieNotifier(OpsgenieConfig{
+		APIKey:     "key",
+		BaseURL:    server.URL,
+		Responders: []OpsgenieResponder{{Type: "team", Name: "ops"}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Title:    "Disk full",
+		Text:     "/var is at 99%",
+		Priority: PriorityHigh,
+		Metadata: map[string]interface{}{
+			"alias":  "disk-full-web-1",
+			"tags":   []string{"disk", "web"},
+			"region": "eu-west-1",
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if alert["priority"] != "P1" {
+		t.Errorf("Expected priority 'P1', got '%v'", alert["priority"])
+	}
+
+	if alert["alias"] != "disk-full-web-1" {
+		t.Errorf("Expected alias 'disk-full-web-1', got '%v'", alert["alias"])
+	}
+
+	details := alert["details"].(map[string]interface{})
+	if details["region"] != "eu-west-1" {
+		t.Errorf("Expected detail region 'eu-west-1', got '%v'", details["region"])
+	}
+
+	if _, ok := details["alias"]; ok {
+		t.Error("Expected alias to be excluded from details")
+	}
+
+	if len(alert["tags"].([]interface{})) != 2 {
+		t.Errorf("Expected 2 tags, got %v", alert["tags"])
+	}
+}
+
+func TestOpsgenieLongMessage(t *testing.T) {
+	var alert map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&alert); err != nil {
+			t.Errorf("Failed to decode alert: %v", err)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"result":"Request will be processed","requestId":"1"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewOpsgenieNotifier(OpsgenieConfig{APIKey: "key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	text := strings.Repeat("ü", 200)
+	if err := notifier.Send(context.Background(), text); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if alert["message"] != strings.Repeat("ü", 127)+"..." {
+		t.Errorf("Expected message truncated to 130 characters, got %v", alert["message"])
+	}
+	if alert["description"] != text {
+		t.Errorf("Expected full text in description, got %v", alert["description"])
+	}
+}
+
+func TestOpsgenieCloseByAlias(t *testing.T) {
+	var path, identifierType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		identifierType = r.URL.Query().Get("identifierType")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier, err := NewOpsgenieNotifier(OpsgenieConfig{APIKey: "key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if err := notifier.Close(context.Background(), "disk-full-web-1", "fixed"); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if path != "/v2/alerts/disk-full-web-1/close" {
+		t.Errorf("Unexpected path: %s", path)
+	}
+
+	if identifierType != "alias" {
+		t.Errorf("Expected identifierType 'alias', got '%s'", identifierType)
+	}
+
+	if err := notifier.Acknowledge(context.Background(), "", ""); err == nil {
+		t.Error("Expected error when alias is missing")
+	}
+}
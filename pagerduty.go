@@ -0,0 +1,377 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// PagerDuty event actions
+const (
+	PagerDutyActionTrigger     = "trigger"
+	PagerDutyActionAcknowledge = "acknowledge"
+	PagerDutyActionResolve     = "resolve"
+)
+
+// PagerDuty severities
+const (
+	PagerDutySeverityCritical = "critical"
+	PagerDutySeverityError    = "error"
+	PagerDutySeverityWarning  = "warning"
+	PagerDutySeverityInfo     = "info"
+)
+
+// PagerDutyNotifier sends events via the PagerDuty Events API v2
+type PagerDutyNotifier struct {
+	name       string
+	routingKey string
+	source     string
+	component  string
+	group      string
+	class      string
+	baseURL    string
+	client     *http.Client
+}
+
+// PagerDutyConfig holds configuration for PagerDuty notifications
+type PagerDutyConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "pagerduty")
+	Name string
+
+	// RoutingKey is the integration key of the Events API v2 service
+	RoutingKey string
+
+	// Source is the default affected system (optional, defaults to the hostname)
+	Source string
+
+	// Component is the default component of the source (optional)
+	Component string
+
+	// Group is the default logical grouping of components (optional)
+	Group string
+
+	// Class is the default class/type of the event (optional)
+	Class string
+
+	// BaseURL overrides the Events API base URL (optional, for testing)
+	BaseURL string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+// pagerDutyEvent is the Events API v2 request body
+type pagerDutyEvent struct {
+	RoutingKey  string            `json:"routing_key"`
+	EventAction string            `json:"event_action"`
+	DedupKey    string            `json:"dedup_key,omitempty"`
+	Payload     *pagerDutyPayload `json:"payload,omitempty"`
+	Images      []pagerDutyImage  `json:"images,omitempty"`
+}
+
+type pagerDutyPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Timestamp     string                 `json:"timestamp,omitempty"`
+	Component     string                 `json:"component,omitempty"`
+	Group         string                 `json:"group,omitempty"`
+	Class         string                 `json:"class,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+type pagerDutyImage struct {
+	Src string `json:"src"`
+	Alt string `json:"alt,omitempty"`
+}
+
+type pagerDutyResponse struct {
+	Status   string   `json:"status"`
+	Message  string   `json:"message"`
+	DedupKey string   `json:"dedup_key"`
+	Errors   []string `json:"errors"`
+}
+
+// NewPagerDutyNotifier creates a new PagerDuty notifier
+func NewPagerDutyNotifier(config PagerDutyConfig) (*PagerDutyNotifier, error) {
+	if config.RoutingKey == "" {
+		return nil, &NotificationError{
+			Provider: "pagerduty",
+			Message:  "routing key is required",
+		}
+	}
+
+	source := config.Source
+	if source == "" {
+		hostname, err := os.Hostname()
+		if err != nil || hostname == "" {
+			hostname = "notify"
+		}
+		source = hostname
+	}
+
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://events.pagerduty.com"
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "pagerduty"
+	}
+
+	return &PagerDutyNotifier{
+		name:       name,
+		routingKey: config.RoutingKey,
+		source:     source,
+		component:  config.Component,
+		group:      config.Group,
+		class:      config.Class,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		client:     newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (p *PagerDutyNotifier) Name() string {
+	return p.name
+}
+
+// Send triggers an incident with the given summary
+func (p *PagerDutyNotifier) Send(ctx context.Context, message string) error {
+	return p.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends an event built from msg.
+// The event action is read from Metadata["action"] (trigger, acknowledge or resolve,
+// defaulting to trigger) and the dedup key from Metadata["dedup_key"].
+func (p *PagerDutyNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	action := metadataString(msg, "action")
+	if action == "" {
+		action = PagerDutyActionTrigger
+	}
+
+	switch action {
+	case PagerDutyActionTrigger:
+		_, err := p.Trigger(ctx, msg)
+		return err
+	case PagerDutyActionAcknowledge, PagerDutyActionResolve:
+		dedupKey := metadataString(msg, "dedup_key")
+		if dedupKey == "" {
+			return &NotificationError{
+				Provider: "pagerduty",
+				Message:  fmt.Sprintf("dedup key is required to %s an incident", action),
+			}
+		}
+		_, err := p.sendEvent(ctx, &pagerDutyEvent{
+			RoutingKey:  p.routingKeyFor(msg.Channel),
+			EventAction: action,
+			DedupKey:    dedupKey,
+		})
+		return err
+	default:
+		return &NotificationError{
+			Provider: "pagerduty",
+			Message:  fmt.Sprintf("unsupported event action: %s", action),
+		}
+	}
+}
+
+// SendRichMessage sends a trigger event with a raw payload.
+// blocks must be a map[string]interface{} holding the Events API "payload" object;
+// channel, if set, overrides the configured routing key.
+func (p *PagerDutyNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	payload, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "pagerduty",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	if _, ok := payload["source"]; !ok {
+		payload["source"] = p.source
+	}
+	if _, ok := payload["severity"]; !ok {
+		payload["severity"] = PagerDutySeverityError
+	}
+
+	event := map[string]interface{}{
+		"routing_key":  p.routingKeyFor(channel),
+		"event_action": PagerDutyActionTrigger,
+		"payload":      payload,
+	}
+
+	var result pagerDutyResponse
+	return p.post(ctx, event, &result)
+}
+
+// Trigger opens (or updates) an incident and returns its dedup key
+func (p *PagerDutyNotifier) Trigger(ctx context.Context, msg *Message) (string, error) {
+	summary := msg.Title
+	if summary == "" {
+		summary = msg.Text
+	}
+
+	if summary == "" {
+		return "", &NotificationError{
+			Provider: "pagerduty",
+			Message:  "message text is required",
+		}
+	}
+
+	// PagerDuty rejects summaries longer than 1024 characters
+	summary = truncate(summary, 1024)
+
+	severity := metadataString(msg, "severity")
+	if severity == "" {
+		severity = pagerDutySeverity(msg.Priority)
+	}
+
+	payload := &pagerDutyPayload{
+		Summary:       summary,
+		Source:        p.source,
+		Severity:      severity,
+		Timestamp:     time.Now().UTC().Format(time.RFC3339),
+		Component:     p.component,
+		Group:         p.group,
+		Class:         p.class,
+		CustomDetails: pagerDutyCustomDetails(msg),
+	}
+
+	if source := metadataString(msg, "source"); source != "" {
+		payload.Source = source
+	}
+	if component := metadataString(msg, "component"); component != "" {
+		payload.Component = component
+	}
+	if group := metadataString(msg, "group"); group != "" {
+		payload.Group = group
+	}
+	if class := metadataString(msg, "class"); class != "" {
+		payload.Class = class
+	}
+
+	event := &pagerDutyEvent{
+		RoutingKey:  p.routingKeyFor(msg.Channel),
+		EventAction: PagerDutyActionTrigger,
+		DedupKey:    metadataString(msg, "dedup_key"),
+		Payload:     payload,
+	}
+
+	for _, att := range msg.Attachments {
+		if att.ImageURL != "" {
+			event.Images = append(event.Images, pagerDutyImage{Src: att.ImageURL, Alt: att.Title})
+		}
+	}
+
+	return p.sendEvent(ctx, event)
+}
+
+// Acknowledge acknowledges the incident identified by dedupKey
+func (p *PagerDutyNotifier) Acknowledge(ctx context.Context, dedupKey string) error {
+	return p.SendWithOptions(ctx, &Message{
+		Metadata: map[string]interface{}{
+			"action":    PagerDutyActionAcknowledge,
+			"dedup_key": dedupKey,
+		},
+	})
+}
+
+// Resolve resolves the incident identified by dedupKey
+func (p *PagerDutyNotifier) Resolve(ctx context.Context, dedupKey string) error {
+	return p.SendWithOptions(ctx, &Message{
+		Metadata: map[string]interface{}{
+			"action":    PagerDutyActionResolve,
+			"dedup_key": dedupKey,
+		},
+	})
+}
+
+// routingKeyFor returns channel if set, otherwise the configured routing key
+func (p *PagerDutyNotifier) routingKeyFor(channel string) string {
+	if channel != "" {
+		return channel
+	}
+	return p.routingKey
+}
+
+// sendEvent posts an event and returns the dedup key assigned by PagerDuty
+func (p *PagerDutyNotifier) sendEvent(ctx context.Context, event *pagerDutyEvent) (string, error) {
+	var result pagerDutyResponse
+	if err := p.post(ctx, event, &result); err != nil {
+		return "", err
+	}
+	return result.DedupKey, nil
+}
+
+// post sends a request to the enqueue endpoint and checks the response status
+func (p *PagerDutyNotifier) post(ctx context.Context, event interface{}, result *pagerDutyResponse) error {
+	err := sendJSON(ctx, p.client, "pagerduty", http.MethodPost, p.baseURL+"/v2/enqueue", nil, event, result)
+	if err != nil {
+		return err
+	}
+
+	if result.Status != "" && result.Status != "success" {
+		return &NotificationError{
+			Provider: "pagerduty",
+			Message:  fmt.Sprintf("API returned error: %s %s", result.Message, strings.Join(result.Errors, "; ")),
+		}
+	}
+
+	return nil
+}
+
+// pagerDutySeverity maps a message priority to a PagerDuty severity
+func pagerDutySeverity(priority string) string {
+	switch priority {
+	case PriorityHigh:
+		return PagerDutySeverityCritical
+	case PriorityLow:
+		return PagerDutySeverityInfo
+	default:
+		return PagerDutySeverityError
+	}
+}
+
+// pagerDutyCustomDetails builds custom_details from the message text, attachments and fields
+func pagerDutyCustomDetails(msg *Message) map[string]interface{} {
+	details := make(map[string]interface{})
+
+	if msg.Title != "" && msg.Text != "" {
+		details["text"] = msg.Text
+	}
+
+	for i, att := range msg.Attachments {
+		key := att.Title
+		if key == "" {
+			key = fmt.Sprintf("attachment_%d", i+1)
+		}
+
+		if len(att.Fields) == 0 {
+			if att.Text != "" {
+				details[key] = att.Text
+			}
+			continue
+		}
+
+		fields := make(map[string]interface{}, len(att.Fields)+1)
+		if att.Text != "" {
+			fields["text"] = att.Text
+		}
+		for _, field := range att.Fields {
+			fields[field.Title] = field.Value
+		}
+		details[key] = fields
+	}
+
+	if len(details) == 0 {
+		return nil
+	}
+	return details
+}
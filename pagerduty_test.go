@@ -0,0 +1,161 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func newTestPagerDutyServer(t *testing.T, events *[]map[string]interface{}) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/enqueue" {
+			t.Errorf("Expected path /v2/enqueue, got %s", r.URL.Path)
+		}
+
+		var event map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+			t.Errorf("Failed to decode event: %v", err)
+			return
+		}
+		*events = append(*events, event)
+
+		dedupKey, _ := event["dedup_key"].(string)
+		if dedupKey == "" {
+			dedupKey = "generated-key"
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"success","message":"Event processed","dedup_key":"` + dedupKey + `"}`))
+	}))
+}
+
+func TestNewPagerDutyNotifier(t *testing.T) {
+	_, err := NewPagerDutyNotifier(PagerDutyConfig{})
+	if err == nil {
+		t.Error("Expected error when routing key is missing")
+	}
+
+	notifier, err := NewPagerDutyNotifier(PagerDutyConfig{RoutingKey: "key", Source: "api"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "pagerduty" {
+		t.Errorf("Expected name 'pagerduty', got '%s'", notifier.Name())
+	}
+}
+
+func TestPagerDutyTrigger(t *testing.T) {
+	var events []map[string]interface{}
+	server := newTestPagerDutyServer(t, &events)
+	defer server.Close()
+
+	notifier, err := NewPagerDutyNotifier(PagerDutyConfig{
+		RoutingKey: "key",
+		Source:     "api",
+		BaseURL:    server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	dedupKey, err := notifier.Trigger(context.Background(), &Message{
+		Title:    "Database down",
+		Text:     "Primary is not responding",
+		Priority: PriorityHigh,
+		Attachments: []Attachment{
+			{
+				Title:  "Details",
+				Fields: []Field{{Title: "Host", Value: "db-1"}},
+			},
+		},
+		Metadata: map[string]interface{}{"dedup_key": "db-down"},
+	})
+	if err != nil {
+		t.Fatalf("Trigger failed: %v", err)
+	}
+
+	if dedupKey != "db-down" {
+		t.Errorf("Expected dedup key 'db-down', got '%s'", dedupKey)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+
+	payload := events[0]["payload"].(map[string]interface{})
+	if payload["severity"] != PagerDutySeverityCritical {
+		t.Errorf("Expected severity 'critical', got '%v'", payload["severity"])
+	}
+
+	if payload["summary"] != "Database down" {
+		t.Errorf("Expected summary 'Database down', got '%v'", payload["summary"])
+	}
+
+	details := payload["custom_details"].(map[string]interface{})
+	fields := details["Details"].(map[string]interface{})
+	if fields["Host"] != "db-1" {
+		t.Errorf("Expected custom detail Host 'db-1', got '%v'", fields["Host"])
+	}
+}
+
+func TestPagerDutyLongSummary(t *testing.T) {
+	var events []map[string]interface{}
+	server := newTestPagerDutyServer(t, &events)
+	defer server.Close()
+
+	notifier, err := NewPagerDutyNotifier(PagerDutyConfig{RoutingKey: "key", Source: "api", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if _, err := notifier.Trigger(context.Background(), &Message{Text: strings.Repeat("é", 1100)}); err != nil {
+		t.Fatalf("Trigger failed: %v", err)
+	}
+
+	summary := events[0]["payload"].(map[string]interface{})["summary"].(string)
+	if summary != strings.Repeat("é", 1021)+"..." {
+		t.Errorf("Expected summary truncated to 1024 characters, got %d characters", utf8.RuneCountInString(summary))
+	}
+}
+
+func TestPagerDutyAcknowledgeAndResolve(t *testing.T) {
+	var events []map[string]interface{}
+	server := newTestPagerDutyServer(t, &events)
+	defer server.Close()
+
+	notifier, err := NewPagerDutyNotifier(PagerDutyConfig{RoutingKey: "key", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := notifier.Acknowledge(ctx, "db-down"); err != nil {
+		t.Fatalf("Acknowledge failed: %v", err)
+	}
+	if err := notifier.Resolve(ctx, "db-down"); err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+
+	if events[0]["event_action"] != PagerDutyActionAcknowledge {
+		t.Errorf("Expected acknowledge action, got '%v'", events[0]["event_action"])
+	}
+
+	if events[1]["event_action"] != PagerDutyActionResolve {
+		t.Errorf("Expected resolve action, got '%v'", events[1]["event_action"])
+	}
+
+	if err := notifier.Resolve(ctx, ""); err == nil {
+		t.Error("Expected error when dedup key is missing")
+	}
+}
@@ -0,0 +1,272 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Pushover priorities
+const (
+	PushoverPriorityLowest    = -2
+	PushoverPriorityLow       = -1
+	PushoverPriorityNormal    = 0
+	PushoverPriorityHigh      = 1
+	PushoverPriorityEmergency = 2
+)
+
+// PushoverNotifier sends push notifications via the Pushover API
+type PushoverNotifier struct {
+	name     string
+	appToken string
+	userKey  string
+	device   string
+	sound    string
+	retry    time.Duration
+	expire   time.Duration
+	baseURL  string
+	client   *http.Client
+}
+
+// PushoverConfig holds configuration for Pushover notifications
+type PushoverConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "pushover")
+	Name string
+
+	// AppToken is the application API token
+	AppToken string
+
+	// UserKey is the default user or group key to notify
+	UserKey string
+
+	// Device restricts delivery to a device name (optional)
+	Device string
+
+	// Sound is the default notification sound (optional, e.g., pushover, siren)
+	Sound string
+
+	// Retry is how often emergency notifications are retried until acknowledged
+	// (optional, defaults to 60s, minimum 30s)
+	Retry time.Duration
+
+	// Expire is how long emergency notifications keep retrying
+	// (optional, defaults to 1h, maximum 3h)
+	Expire time.Duration
+
+	// BaseURL overrides the API base URL (optional, for testing)
+	BaseURL string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type pushoverResponse struct {
+	Status  int      `json:"status"`
+	Request string   `json:"request"`
+	Receipt string   `json:"receipt"`
+	Errors  []string `json:"errors"`
+}
+
+// NewPushoverNotifier creates a new Pushover notifier
+func NewPushoverNotifier(config PushoverConfig) (*PushoverNotifier, error) {
+	if config.AppToken == "" {
+		return nil, &NotificationError{
+			Provider: "pushover",
+			Message:  "app token is required",
+		}
+	}
+
+	if config.UserKey == "" {
+		return nil, &NotificationError{
+			Provider: "pushover",
+			Message:  "user key is required",
+		}
+	}
+
+	retry := config.Retry
+	if retry == 0 {
+		retry = time.Minute
+	}
+	if retry < 30*time.Second {
+		retry = 30 * time.Second
+	}
+
+	expire := config.Expire
+	if expire == 0 {
+		expire = time.Hour
+	}
+	if expire > 3*time.Hour {
+		expire = 3 * time.Hour
+	}
+
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.pushover.net"
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "pushover"
+	}
+
+	return &PushoverNotifier{
+		name:     name,
+		appToken: config.AppToken,
+		userKey:  config.UserKey,
+		device:   config.Device,
+		sound:    config.Sound,
+		retry:    retry,
+		expire:   expire,
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		client:   newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (p *PushoverNotifier) Name() string {
+	return p.name
+}
+
+// Send sends a simple text message
+func (p *PushoverNotifier) Send(ctx context.Context, message string) error {
+	return p.SendWithOptions(ctx, &Message{
+		Text:    message,
+		Channel: p.userKey,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Channel overrides the user key; Metadata keys "priority" (-2 to 2, where 2 is
+// emergency), "sound", "device", "url" and "url_title" map to Pushover parameters.
+func (p *PushoverNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	_, err := p.Push(ctx, msg)
+	return err
+}
+
+// Push sends a message and returns the receipt of emergency-priority notifications
+// (empty for other priorities), which can be used to poll acknowledgement status.
+func (p *PushoverNotifier) Push(ctx context.Context, msg *Message) (string, error) {
+	if msg.Text == "" {
+		return "", &NotificationError{
+			Provider: "pushover",
+			Message:  "message text is required",
+		}
+	}
+
+	user := msg.Channel
+	if user == "" {
+		user = p.userKey
+	}
+
+	priority, ok := metadataInt(msg, "priority")
+	if !ok || priority < PushoverPriorityLowest || priority > PushoverPriorityEmergency {
+		priority = pushoverPriority(msg.Priority)
+	}
+
+	text := msg.Text
+	if len(msg.Attachments) > 0 {
+		text += "\n" + plainTextAttachments(msg.Attachments)
+	}
+
+	form := url.Values{}
+	form.Set("token", p.appToken)
+	form.Set("user", user)
+	form.Set("message", text)
+	form.Set("priority", strconv.Itoa(priority))
+
+	if msg.Title != "" {
+		form.Set("title", msg.Title)
+	}
+
+	sound := metadataString(msg, "sound")
+	if sound == "" {
+		sound = p.sound
+	}
+	if sound != "" {
+		form.Set("sound", sound)
+	}
+
+	device := metadataString(msg, "device")
+	if device == "" {
+		device = p.device
+	}
+	if device != "" {
+		form.Set("device", device)
+	}
+
+	if link := metadataString(msg, "url"); link != "" {
+		form.Set("url", link)
+		if title := metadataString(msg, "url_title"); title != "" {
+			form.Set("url_title", title)
+		}
+	}
+
+	// Emergency priority requires retry and expire parameters
+	if priority == PushoverPriorityEmergency {
+		form.Set("retry", strconv.Itoa(int(p.retry.Seconds())))
+		form.Set("expire", strconv.Itoa(int(p.expire.Seconds())))
+	}
+
+	return p.post(ctx, form)
+}
+
+// SendRichMessage sends a message with raw API parameters.
+// blocks must be a map[string]string of Pushover message parameters; token and
+// user (or channel) are filled in from the configuration.
+func (p *PushoverNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	params, ok := blocks.(map[string]string)
+	if !ok {
+		return &NotificationError{
+			Provider: "pushover",
+			Message:  "blocks must be of type map[string]string",
+		}
+	}
+
+	form := url.Values{}
+	for key, value := range params {
+		form.Set(key, value)
+	}
+
+	form.Set("token", p.appToken)
+	if channel != "" {
+		form.Set("user", channel)
+	} else if form.Get("user") == "" {
+		form.Set("user", p.userKey)
+	}
+
+	_, err := p.post(ctx, form)
+	return err
+}
+
+// post sends the form to the messages endpoint and returns the emergency receipt
+func (p *PushoverNotifier) post(ctx context.Context, form url.Values) (string, error) {
+	var result pushoverResponse
+	if err := sendForm(ctx, p.client, "pushover", p.baseURL+"/1/messages.json", nil, form, &result); err != nil {
+		return "", err
+	}
+
+	if result.Status != 1 {
+		return "", &NotificationError{
+			Provider: "pushover",
+			Message:  fmt.Sprintf("API returned error: %s", strings.Join(result.Errors, "; ")),
+		}
+	}
+
+	return result.Receipt, nil
+}
+
+// pushoverPriority maps a message priority to a Pushover priority
+func pushoverPriority(priority string) int {
+	switch priority {
+	case PriorityHigh:
+		return PushoverPriorityHigh
+	case PriorityLow:
+		return PushoverPriorityLow
+	default:
+		return PushoverPriorityNormal
+	}
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPushoverEmergency(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+		form = map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		_, _ = w.Write([]byte(`{"status":1,"request":"req","receipt":"rcpt"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewPushoverNotifier(PushoverConfig{AppToken: "app", UserKey: "user", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	receipt, err := notifier.Push(context.Background(), &Message{
+		Text:     "Site down",
+		Metadata: map[string]interface{}{"priority": PushoverPriorityEmergency, "sound": "siren"},
+	})
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+
+	if receipt != "rcpt" {
+		t.Errorf("Expected receipt 'rcpt', got '%s'", receipt)
+	}
+
+	if form["priority"] != "2" || form["retry"] != "60" || form["expire"] != "3600" || form["sound"] != "siren" {
+		t.Errorf("Unexpected form: %v", form)
+	}
+}
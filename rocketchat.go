@@ -0,0 +1,207 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// RocketChatNotifier sends notifications to Rocket.Chat via incoming webhooks or the REST API
+type RocketChatNotifier struct {
+	name           string
+	webhookURL     string
+	serverURL      string
+	userID         string
+	authToken      string
+	defaultChannel string
+	alias          string
+	emoji          string
+	avatarURL      string
+	client         *http.Client
+}
+
+// RocketChatConfig holds configuration for Rocket.Chat notifications
+type RocketChatConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "rocketchat")
+	Name string
+
+	// WebhookURL for incoming webhook integrations (alternative to ServerURL and credentials)
+	WebhookURL string
+
+	// ServerURL is the Rocket.Chat server base URL (e.g., https://chat.example.com)
+	ServerURL string
+
+	// UserID is the ID of the user or bot the AuthToken belongs to
+	UserID string
+
+	// AuthToken is a personal access token used with the REST API
+	AuthToken string
+
+	// DefaultChannel is the default channel (#channel, @user or room ID)
+	DefaultChannel string
+
+	// Alias overrides the displayed username (optional)
+	Alias string
+
+	// Emoji overrides the avatar with an emoji (optional, e.g., :robot:)
+	Emoji string
+
+	// AvatarURL overrides the avatar image (optional)
+	AvatarURL string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type rocketChatResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Message struct {
+		ID string `json:"_id"`
+	} `json:"message"`
+}
+
+// NewRocketChatNotifier creates a new Rocket.Chat notifier
+func NewRocketChatNotifier(config RocketChatConfig) (*RocketChatNotifier, error) {
+	if config.WebhookURL == "" && (config.ServerURL == "" || config.UserID == "" || config.AuthToken == "") {
+		return nil, &NotificationError{
+			Provider: "rocketchat",
+			Message:  "either webhook URL or server URL, user ID and auth token are required",
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "rocketchat"
+	}
+
+	return &RocketChatNotifier{
+		name:           name,
+		webhookURL:     config.WebhookURL,
+		serverURL:      strings.TrimRight(config.ServerURL, "/"),
+		userID:         config.UserID,
+		authToken:      config.AuthToken,
+		defaultChannel: config.DefaultChannel,
+		alias:          config.Alias,
+		emoji:          config.Emoji,
+		avatarURL:      config.AvatarURL,
+		client:         newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (r *RocketChatNotifier) Name() string {
+	return r.name
+}
+
+// Send sends a simple text message
+func (r *RocketChatNotifier) Send(ctx context.Context, message string) error {
+	return r.SendWithOptions(ctx, &Message{
+		Text:    message,
+		Channel: r.defaultChannel,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Metadata["tmid"] replies in the thread of the given message.
+func (r *RocketChatNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	_, err := r.Post(ctx, msg)
+	return err
+}
+
+// Post sends a message and returns the ID of the created message.
+// The ID is only available in REST API mode and can be used as Metadata["tmid"]
+// to thread replies.
+func (r *RocketChatNotifier) Post(ctx context.Context, msg *Message) (string, error) {
+	if msg.Text == "" && len(msg.Attachments) == 0 {
+		return "", &NotificationError{
+			Provider: "rocketchat",
+			Message:  "message text is required",
+		}
+	}
+
+	text := msg.Text
+	if msg.Title != "" {
+		text = fmt.Sprintf("*%s*\n%s", msg.Title, msg.Text)
+	}
+
+	channel := msg.Channel
+	if channel == "" {
+		channel = r.defaultChannel
+	}
+
+	return r.post(ctx, channel, text, convertSlackStyleAttachments(msg.Attachments), metadataString(msg, "tmid"))
+}
+
+// SendRichMessage sends a message made only of attachments.
+// blocks must be of type []Attachment.
+func (r *RocketChatNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	attachments, ok := blocks.([]Attachment)
+	if !ok {
+		return &NotificationError{
+			Provider: "rocketchat",
+			Message:  "blocks must be of type []Attachment",
+		}
+	}
+
+	if channel == "" {
+		channel = r.defaultChannel
+	}
+
+	_, err := r.post(ctx, channel, "", convertSlackStyleAttachments(attachments), "")
+	return err
+}
+
+// post sends the message through the webhook or the REST API depending on configuration
+func (r *RocketChatNotifier) post(ctx context.Context, channel, text string, attachments []slackStyleAttachment, tmid string) (string, error) {
+	payload := map[string]interface{}{
+		"text": text,
+	}
+	if channel != "" {
+		payload["channel"] = channel
+	}
+	if r.alias != "" {
+		payload["alias"] = r.alias
+	}
+	if r.emoji != "" {
+		payload["emoji"] = r.emoji
+	}
+	if r.avatarURL != "" {
+		payload["avatar"] = r.avatarURL
+	}
+	if len(attachments) > 0 {
+		payload["attachments"] = attachments
+	}
+	if tmid != "" {
+		payload["tmid"] = tmid
+	}
+
+	url := r.webhookURL
+	header := http.Header{}
+	if url == "" {
+		if channel == "" {
+			return "", &NotificationError{
+				Provider: "rocketchat",
+				Message:  "channel is required",
+			}
+		}
+		url = r.serverURL + "/api/v1/chat.postMessage"
+		header.Set("X-User-Id", r.userID)
+		header.Set("X-Auth-Token", r.authToken)
+	}
+
+	var result rocketChatResponse
+	if err := sendJSON(ctx, r.client, "rocketchat", http.MethodPost, url, header, payload, &result); err != nil {
+		return "", err
+	}
+
+	if !result.Success {
+		return "", &NotificationError{
+			Provider: "rocketchat",
+			Message:  fmt.Sprintf("API returned error: %s", result.Error),
+		}
+	}
+
+	return result.Message.ID, nil
+}
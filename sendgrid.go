@@ -0,0 +1,265 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"strings"
+)
+
+// SendGridNotifier sends email notifications through the SendGrid v3 Mail Send API
+type SendGridNotifier struct {
+	name      string
+	apiKey    string
+	from      string
+	fromName  string
+	defaultTo []string
+	baseURL   string
+	client    *http.Client
+}
+
+// SendGridConfig holds configuration for SendGrid notifications
+type SendGridConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "sendgrid")
+	Name string
+
+	// APIKey is the SendGrid API key with Mail Send permission
+	APIKey string
+
+	// From is the verified sender address
+	From string
+
+	// FromName is the sender display name (optional)
+	FromName string
+
+	// DefaultTo are the recipients used when Message.Channel is empty (optional)
+	DefaultTo []string
+
+	// BaseURL overrides the API base URL (optional, defaults to https://api.sendgrid.com)
+	BaseURL string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridErrorResponse struct {
+	Errors []struct {
+		Message string `json:"message"`
+		Field   string `json:"field"`
+	} `json:"errors"`
+}
+
+// NewSendGridNotifier creates a new SendGrid notifier
+func NewSendGridNotifier(config SendGridConfig) (*SendGridNotifier, error) {
+	if config.APIKey == "" {
+		return nil, &NotificationError{
+			Provider: "sendgrid",
+			Message:  "API key is required",
+		}
+	}
+
+	if _, err := mail.ParseAddress(config.From); err != nil {
+		return nil, &NotificationError{
+			Provider: "sendgrid",
+			Message:  "a valid from address is required",
+			Err:      err,
+		}
+	}
+
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.sendgrid.com"
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "sendgrid"
+	}
+
+	return &SendGridNotifier{
+		name:      name,
+		apiKey:    config.APIKey,
+		from:      config.From,
+		fromName:  config.FromName,
+		defaultTo: config.DefaultTo,
+		baseURL:   strings.TrimRight(baseURL, "/"),
+		client:    newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (s *SendGridNotifier) Name() string {
+	return s.name
+}
+
+// Send sends a simple text email to the default recipients
+func (s *SendGridNotifier) Send(ctx context.Context, message string) error {
+	return s.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends an email.
+// Channel is a comma-separated list of recipients, Title is the subject and the
+// message is sent as both plain text and HTML. Metadata keys "cc", "bcc",
+// "reply_to" and "email_files" add recipients and attachments.
+func (s *SendGridNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	_, err := s.Post(ctx, msg)
+	return err
+}
+
+// Post sends an email and returns the SendGrid message ID
+func (s *SendGridNotifier) Post(ctx context.Context, msg *Message) (string, error) {
+	email, err := renderEmail("sendgrid", msg, s.defaultTo)
+	if err != nil {
+		return "", err
+	}
+
+	personalization := map[string]interface{}{
+		"to": sendGridAddresses(email.To),
+	}
+	if len(email.Cc) > 0 {
+		personalization["cc"] = sendGridAddresses(email.Cc)
+	}
+	if len(email.Bcc) > 0 {
+		personalization["bcc"] = sendGridAddresses(email.Bcc)
+	}
+
+	payload := map[string]interface{}{
+		"personalizations": []interface{}{personalization},
+		"from":             sendGridAddress{Email: s.from, Name: s.fromName},
+		"subject":          email.Subject,
+		"content": []map[string]string{
+			{"type": "text/plain", "value": email.Text},
+			{"type": "text/html", "value": email.HTML},
+		},
+	}
+
+	if email.ReplyTo != "" {
+		payload["reply_to"] = sendGridAddress{Email: email.ReplyTo}
+	}
+	if len(email.Headers) > 0 {
+		payload["headers"] = email.Headers
+	}
+	if len(email.Files) > 0 {
+		attachments := make([]map[string]string, len(email.Files))
+		for i, file := range email.Files {
+			attachments[i] = map[string]string{
+				"content":     base64.StdEncoding.EncodeToString(file.Content),
+				"type":        file.ContentType,
+				"filename":    file.Filename,
+				"disposition": "attachment",
+			}
+		}
+		payload["attachments"] = attachments
+	}
+
+	return s.send(ctx, payload)
+}
+
+// SendRichMessage sends a raw v3 Mail Send payload.
+// blocks must be a map[string]interface{}; "from" and "personalizations" are
+// filled in from the configuration and channel when missing.
+func (s *SendGridNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	payload, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "sendgrid",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	if _, ok := payload["from"]; !ok {
+		payload["from"] = sendGridAddress{Email: s.from, Name: s.fromName}
+	}
+	if _, ok := payload["personalizations"]; !ok {
+		to := s.defaultTo
+		if channel != "" {
+			to = splitList(channel)
+		}
+		if len(to) == 0 {
+			return &NotificationError{
+				Provider: "sendgrid",
+				Message:  "at least one recipient is required",
+			}
+		}
+		payload["personalizations"] = []interface{}{map[string]interface{}{"to": sendGridAddresses(to)}}
+	}
+
+	_, err := s.send(ctx, payload)
+	return err
+}
+
+// send posts payload to /v3/mail/send and returns the X-Message-Id header
+func (s *SendGridNotifier) send(ctx context.Context, payload interface{}) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "sendgrid",
+			Message:  "failed to marshal request",
+			Err:      err,
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/v3/mail/send", bytes.NewReader(body))
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "sendgrid",
+			Message:  "failed to create request",
+			Err:      err,
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "sendgrid",
+			Message:  "failed to send request",
+			Err:      err,
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		var result sendGridErrorResponse
+		if json.NewDecoder(resp.Body).Decode(&result) == nil && len(result.Errors) > 0 {
+			messages := make([]string, len(result.Errors))
+			for i, e := range result.Errors {
+				messages[i] = e.Message
+				if e.Field != "" {
+					messages[i] = e.Field + ": " + e.Message
+				}
+			}
+			return "", &NotificationError{
+				Provider: "sendgrid",
+				Message:  fmt.Sprintf("API request failed with status %d: %s", resp.StatusCode, strings.Join(messages, "; ")),
+			}
+		}
+		return "", &NotificationError{
+			Provider: "sendgrid",
+			Message:  fmt.Sprintf("API request failed with status %d", resp.StatusCode),
+		}
+	}
+
+	return resp.Header.Get("X-Message-Id"), nil
+}
+
+// sendGridAddresses converts addresses to SendGrid email objects
+func sendGridAddresses(addresses []string) []sendGridAddress {
+	result := make([]sendGridAddress, len(addresses))
+	for i, address := range addresses {
+		result[i] = sendGridAddress{Email: address}
+	}
+	return result
+}
@@ -0,0 +1,116 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewSendGridNotifier(t *testing.T) {
+	_, err := NewSendGridNotifier(SendGridConfig{From: "alerts@example.com"})
+	if err == nil {
+		t.Error("Expected error when API key is missing")
+	}
+
+	_, err = NewSendGridNotifier(SendGridConfig{APIKey: "key", From: "not an address"})
+	if err == nil {
+		t.Error("Expected error when from address is invalid")
+	}
+
+	notifier, err := NewSendGridNotifier(SendGridConfig{APIKey: "key", From: "alerts@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "sendgrid" {
+		t.Errorf("Expected name 'sendgrid', got '%s'", notifier.Name())
+	}
+}
+
+func TestSendGridPost(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3/mail/send" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("Unexpected Authorization header: %s", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		w.Header().Set("X-Message-Id", "sg-1")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	notifier, err := NewSendGridNotifier(SendGridConfig{
+		APIKey:    "key",
+		From:      "alerts@example.com",
+		FromName:  "Alerts",
+		DefaultTo: []string{"ops@example.com"},
+		BaseURL:   server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	messageID, err := notifier.Post(context.Background(), &Message{
+		Title:    "Backup failed",
+		Text:     "See attached log",
+		Metadata: map[string]interface{}{"email_files": []EmailFile{{Filename: "backup.log", Content: []byte("error")}}},
+	})
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	if messageID != "sg-1" {
+		t.Errorf("Expected message ID sg-1, got %s", messageID)
+	}
+
+	if body["subject"] != "Backup failed" {
+		t.Errorf("Expected subject 'Backup failed', got %v", body["subject"])
+	}
+
+	from := body["from"].(map[string]interface{})
+	if from["email"] != "alerts@example.com" || from["name"] != "Alerts" {
+		t.Errorf("Unexpected from: %v", from)
+	}
+
+	to := body["personalizations"].([]interface{})[0].(map[string]interface{})["to"].([]interface{})
+	if to[0].(map[string]interface{})["email"] != "ops@example.com" {
+		t.Errorf("Expected default recipient, got %v", to)
+	}
+
+	if len(body["content"].([]interface{})) != 2 {
+		t.Errorf("Expected text and HTML content, got %v", body["content"])
+	}
+
+	attachment := body["attachments"].([]interface{})[0].(map[string]interface{})
+	if attachment["filename"] != "backup.log" || attachment["content"] != "ZXJyb3I=" {
+		t.Errorf("Unexpected attachment: %v", attachment)
+	}
+}
+
+func TestSendGridError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errors":[{"message":"The from address does not match a verified Sender Identity","field":"from"}]}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewSendGridNotifier(SendGridConfig{APIKey: "key", From: "alerts@example.com", BaseURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{Text: "hi", Channel: "ops@example.com"})
+	if err == nil {
+		t.Fatal("Expected error for rejected request")
+	}
+	if notifyErr, ok := err.(*NotificationError); !ok || notifyErr.Message != "API request failed with status 400: from: The from address does not match a verified Sender Identity" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
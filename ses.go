@@ -0,0 +1,299 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SESNotifier sends email notifications through the Amazon SES v2 API
+type SESNotifier struct {
+	name             string
+	credentials      awsCredentials
+	region           string
+	endpoint         string
+	from             string
+	fromName         string
+	defaultTo        []string
+	configurationSet string
+	client           *http.Client
+}
+
+// SESConfig holds configuration for Amazon SES notifications
+type SESConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "ses")
+	Name string
+
+	// Region is the AWS region (optional, defaults to AWS_REGION)
+	Region string
+
+	// AccessKeyID and SecretAccessKey are the AWS credentials
+	// (optional, default to AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY)
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is the session token for temporary credentials (optional)
+	SessionToken string
+
+	// From is the verified sender address
+	From string
+
+	// FromName is the sender display name (optional)
+	FromName string
+
+	// DefaultTo are the recipients used when Message.Channel is empty (optional)
+	DefaultTo []string
+
+	// ConfigurationSet is the SES configuration set to use (optional)
+	ConfigurationSet string
+
+	// Endpoint overrides the SES endpoint (optional, for testing or local stand-ins)
+	Endpoint string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type sesSendResponse struct {
+	MessageID string `json:"MessageId"`
+}
+
+type sesErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// NewSESNotifier creates a new Amazon SES notifier
+func NewSESNotifier(config SESConfig) (*SESNotifier, error) {
+	region := resolveAWSRegion(config.Region)
+	if region == "" {
+		return nil, &NotificationError{
+			Provider: "ses",
+			Message:  "region is required",
+		}
+	}
+
+	credentials := resolveAWSCredentials(config.AccessKeyID, config.SecretAccessKey, config.SessionToken)
+	if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+		return nil, &NotificationError{
+			Provider: "ses",
+			Message:  "AWS credentials are required",
+		}
+	}
+
+	if _, err := mail.ParseAddress(config.From); err != nil {
+		return nil, &NotificationError{
+			Provider: "ses",
+			Message:  "a valid from address is required",
+			Err:      err,
+		}
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://email.%s.amazonaws.com", region)
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "ses"
+	}
+
+	return &SESNotifier{
+		name:             name,
+		credentials:      credentials,
+		region:           region,
+		endpoint:         strings.TrimRight(endpoint, "/"),
+		from:             config.From,
+		fromName:         config.FromName,
+		defaultTo:        config.DefaultTo,
+		configurationSet: config.ConfigurationSet,
+		client:           newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (s *SESNotifier) Name() string {
+	return s.name
+}
+
+// Send sends a simple text email to the default recipients
+func (s *SESNotifier) Send(ctx context.Context, message string) error {
+	return s.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends an email.
+// Channel is a comma-separated list of recipients, Title is the subject and the
+// message is sent as both plain text and HTML. Metadata keys "cc", "bcc",
+// "reply_to" and "email_files" add recipients and attachments.
+func (s *SESNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	_, err := s.Post(ctx, msg)
+	return err
+}
+
+// Post sends an email and returns the SES message ID
+func (s *SESNotifier) Post(ctx context.Context, msg *Message) (string, error) {
+	email, err := renderEmail("ses", msg, s.defaultTo)
+	if err != nil {
+		return "", err
+	}
+
+	simple := map[string]interface{}{
+		"Subject": sesContent(email.Subject),
+		"Body": map[string]interface{}{
+			"Text": sesContent(email.Text),
+			"Html": sesContent(email.HTML),
+		},
+	}
+
+	if len(email.Headers) > 0 {
+		names := make([]string, 0, len(email.Headers))
+		for name := range email.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		headers := make([]map[string]string, len(names))
+		for i, name := range names {
+			headers[i] = map[string]string{"Name": name, "Value": email.Headers[name]}
+		}
+		simple["Headers"] = headers
+	}
+
+	if len(email.Files) > 0 {
+		attachments := make([]map[string]string, len(email.Files))
+		for i, file := range email.Files {
+			attachments[i] = map[string]string{
+				"FileName":           file.Filename,
+				"ContentType":        file.ContentType,
+				"ContentDisposition": "ATTACHMENT",
+				"RawContent":         base64.StdEncoding.EncodeToString(file.Content),
+			}
+		}
+		simple["Attachments"] = attachments
+	}
+
+	destination := map[string]interface{}{
+		"ToAddresses": email.To,
+	}
+	if len(email.Cc) > 0 {
+		destination["CcAddresses"] = email.Cc
+	}
+	if len(email.Bcc) > 0 {
+		destination["BccAddresses"] = email.Bcc
+	}
+
+	payload := map[string]interface{}{
+		"Destination": destination,
+		"Content":     map[string]interface{}{"Simple": simple},
+	}
+	if email.ReplyTo != "" {
+		payload["ReplyToAddresses"] = []string{email.ReplyTo}
+	}
+
+	return s.send(ctx, payload)
+}
+
+// SendRichMessage sends a raw SendEmail payload.
+// blocks must be a map[string]interface{} (e.g., with Content.Template);
+// "FromEmailAddress" and "Destination" are filled in from the configuration
+// and channel when missing.
+func (s *SESNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	payload, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "ses",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	if _, ok := payload["Destination"]; !ok {
+		to := s.defaultTo
+		if channel != "" {
+			to = splitList(channel)
+		}
+		if len(to) == 0 {
+			return &NotificationError{
+				Provider: "ses",
+				Message:  "at least one recipient is required",
+			}
+		}
+		payload["Destination"] = map[string]interface{}{"ToAddresses": to}
+	}
+
+	_, err := s.send(ctx, payload)
+	return err
+}
+
+// send signs and posts payload to /v2/email/outbound-emails and returns the message ID
+func (s *SESNotifier) send(ctx context.Context, payload map[string]interface{}) (string, error) {
+	if _, ok := payload["FromEmailAddress"]; !ok {
+		payload["FromEmailAddress"] = emailAddress(s.fromName, s.from)
+	}
+	if _, ok := payload["ConfigurationSetName"]; !ok && s.configurationSet != "" {
+		payload["ConfigurationSetName"] = s.configurationSet
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "ses",
+			Message:  "failed to marshal request",
+			Err:      err,
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint+"/v2/email/outbound-emails", bytes.NewReader(body))
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "ses",
+			Message:  "failed to create request",
+			Err:      err,
+		}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	signV4(req, body, s.credentials, s.region, "ses", time.Now())
+
+	status, respBody, err := doRequest(s.client, "ses", req)
+	if err != nil {
+		return "", err
+	}
+
+	if status < 200 || status >= 300 {
+		var result sesErrorResponse
+		if json.Unmarshal(respBody, &result) == nil && result.Message != "" {
+			return "", &NotificationError{
+				Provider: "ses",
+				Message:  fmt.Sprintf("API request failed with status %d: %s", status, result.Message),
+			}
+		}
+		return "", &NotificationError{
+			Provider: "ses",
+			Message:  fmt.Sprintf("API request failed with status %d: %s", status, string(respBody)),
+		}
+	}
+
+	var result sesSendResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return "", &NotificationError{
+			Provider: "ses",
+			Message:  "failed to parse response",
+			Err:      err,
+		}
+	}
+
+	return result.MessageID, nil
+}
+
+// sesContent returns an SES content object with UTF-8 charset
+func sesContent(data string) map[string]string {
+	return map[string]string{"Data": data, "Charset": "UTF-8"}
+}
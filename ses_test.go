@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewSESNotifier(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	_, err := NewSESNotifier(SESConfig{Region: "us-east-1", From: "alerts@example.com"})
+	if err == nil {
+		t.Error("Expected error when credentials are missing")
+	}
+
+	notifier, err := NewSESNotifier(SESConfig{Region: "us-east-1", AccessKeyID: "AKID", SecretAccessKey: "secret", From: "alerts@example.com"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "ses" {
+		t.Errorf("Expected name 'ses', got '%s'", notifier.Name())
+	}
+}
+
+func TestSESPost(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/email/outbound-emails" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/ses/aws4_request") {
+			t.Errorf("Unexpected Authorization header: %s", r.Header.Get("Authorization"))
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"MessageId":"ses-1"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewSESNotifier(SESConfig{
+		Region:           "eu-west-1",
+		AccessKeyID:      "AKID",
+		SecretAccessKey:  "secret",
+		From:             "alerts@example.com",
+		DefaultTo:        []string{"ops@example.com"},
+		ConfigurationSet: "notifications",
+		Endpoint:         server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	messageID, err := notifier.Post(context.Background(), &Message{
+		Title:    "Certificate expiring",
+		Text:     "example.com expires in 7 days",
+		Metadata: map[string]interface{}{"bcc": []string{"audit@example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	if messageID != "ses-1" {
+		t.Errorf("Expected message ID ses-1, got %s", messageID)
+	}
+
+	if body["FromEmailAddress"] != "alerts@example.com" || body["ConfigurationSetName"] != "notifications" {
+		t.Errorf("Unexpected payload: %v", body)
+	}
+
+	destination := body["Destination"].(map[string]interface{})
+	if destination["ToAddresses"].([]interface{})[0] != "ops@example.com" || destination["BccAddresses"].([]interface{})[0] != "audit@example.com" {
+		t.Errorf("Unexpected destination: %v", destination)
+	}
+
+	simple := body["Content"].(map[string]interface{})["Simple"].(map[string]interface{})
+	subject := simple["Subject"].(map[string]interface{})
+	if subject["Data"] != "Certificate expiring" {
+		t.Errorf("Unexpected subject: %v", subject)
+	}
+}
+
+func TestSESError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Amzn-ErrorType", "MessageRejected")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"Email address is not verified."}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewSESNotifier(SESConfig{Region: "us-east-1", AccessKeyID: "AKID", SecretAccessKey: "secret", From: "alerts@example.com", Endpoint: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{Text: "hi", Channel: "ops@example.com"})
+	if err == nil || !strings.Contains(err.Error(), "Email address is not verified.") {
+		t.Errorf("Expected SES error message, got %v", err)
+	}
+}
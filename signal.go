@@ -0,0 +1,225 @@
+package notify
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// SignalNotifier sends notifications through a signal-cli REST API server
+type SignalNotifier struct {
+	name       string
+	serverURL  string
+	number     string
+	recipients []string
+	client     *http.Client
+}
+
+// SignalConfig holds configuration for Signal notifications
+type SignalConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "signal")
+	Name string
+
+	// ServerURL is the signal-cli REST API base URL (e.g., http://localhost:8080)
+	ServerURL string
+
+	// Number is the registered sender phone number
+	Number string
+
+	// DefaultRecipients are phone numbers, usernames or group IDs ("group.…")
+	// used when Message.Channel is empty (optional)
+	DefaultRecipients []string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type signalResponse struct {
+	Timestamp string `json:"timestamp"`
+}
+
+// NewSignalNotifier creates a new Signal notifier
+func NewSignalNotifier(config SignalConfig) (*SignalNotifier, error) {
+	if config.ServerURL == "" {
+		return nil, &NotificationError{
+			Provider: "signal",
+			Message:  "server URL is required",
+		}
+	}
+
+	if config.Number == "" {
+		return nil, &NotificationError{
+			Provider: "signal",
+			Message:  "sender number is required",
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "signal"
+	}
+
+	return &SignalNotifier{
+		name:       name,
+		serverURL:  strings.TrimRight(config.ServerURL, "/"),
+		number:     config.Number,
+		recipients: config.DefaultRecipients,
+		client:     newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (s *SignalNotifier) Name() string {
+	return s.name
+}
+
+// Send sends a simple text message
+func (s *SignalNotifier) Send(ctx context.Context, message string) error {
+	return s.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Message.Channel is a comma-separated list of recipients and group IDs.
+// Attachment images are downloaded and sent as Signal attachments, and
+// Metadata "base64_attachments" passes pre-encoded attachments through.
+func (s *SignalNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	_, err := s.Post(ctx, msg)
+	return err
+}
+
+// Post sends a message and returns the timestamp that identifies it in Signal
+func (s *SignalNotifier) Post(ctx context.Context, msg *Message) (string, error) {
+	if msg.Text == "" {
+		return "", &NotificationError{
+			Provider: "signal",
+			Message:  "message text is required",
+		}
+	}
+
+	text := msg.Text
+	if msg.Title != "" {
+		text = "**" + msg.Title + "**\n" + text
+	}
+	if len(msg.Attachments) > 0 {
+		if details := plainTextAttachments(msg.Attachments); details != "" {
+			text += "\n\n" + details
+		}
+	}
+
+	attachments := metadataStrings(msg, "base64_attachments")
+	for _, att := range msg.Attachments {
+		if att.ImageURL == "" {
+			continue
+		}
+		encoded, err := s.download(ctx, att.ImageURL)
+		if err != nil {
+			return "", err
+		}
+		attachments = append(attachments, encoded)
+	}
+
+	payload := map[string]interface{}{
+		"message":   text,
+		"text_mode": "styled",
+	}
+	if len(attachments) > 0 {
+		payload["base64_attachments"] = attachments
+	}
+
+	return s.send(ctx, msg.Channel, payload)
+}
+
+// SendRichMessage sends a raw /v2/send payload.
+// blocks must be a map[string]interface{}; "number" and "recipients" are filled
+// in from the configuration and channel when missing.
+func (s *SignalNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	payload, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "signal",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	_, err := s.send(ctx, channel, payload)
+	return err
+}
+
+// send resolves recipients and posts payload to /v2/send
+func (s *SignalNotifier) send(ctx context.Context, channel string, payload map[string]interface{}) (string, error) {
+	if _, ok := payload["recipients"]; !ok {
+		recipients := s.recipients
+		if channel != "" {
+			recipients = splitList(channel)
+		}
+		if len(recipients) == 0 {
+			return "", &NotificationError{
+				Provider: "signal",
+				Message:  "at least one recipient is required",
+			}
+		}
+		payload["recipients"] = recipients
+	}
+
+	if _, ok := payload["number"]; !ok {
+		payload["number"] = s.number
+	}
+
+	var result signalResponse
+	if err := sendJSON(ctx, s.client, "signal", http.MethodPost, s.serverURL+"/v2/send", nil, payload, &result); err != nil {
+		return "", err
+	}
+
+	return result.Timestamp, nil
+}
+
+// download fetches an attachment and encodes it as a data URI with a filename
+func (s *SignalNotifier) download(ctx context.Context, imageURL string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "signal",
+			Message:  "failed to create attachment request",
+			Err:      err,
+		}
+	}
+
+	status, body, err := doRequest(s.client, "signal", req)
+	if err != nil {
+		return "", err
+	}
+	if status < 200 || status >= 300 {
+		return "", &NotificationError{
+			Provider: "signal",
+			Message:  fmt.Sprintf("failed to download attachment %s: status %d", imageURL, status),
+		}
+	}
+
+	filename := path.Base(req.URL.Path)
+	contentType := mime.TypeByExtension(path.Ext(filename))
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	if i := strings.Index(contentType, ";"); i >= 0 {
+		contentType = contentType[:i]
+	}
+
+	return fmt.Sprintf("data:%s;filename=%s;base64,%s", contentType, filename, base64.StdEncoding.EncodeToString(body)), nil
+}
+
+// splitList splits a comma-separated list, trimming spaces and dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
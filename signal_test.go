@@ -0,0 +1,96 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewSignalNotifier(t *testing.T) {
+	_, err := NewSignalNotifier(SignalConfig{Number: "+15550001111"})
+	if err == nil {
+		t.Error("Expected error when server URL is missing")
+	}
+
+	_, err = NewSignalNotifier(SignalConfig{ServerURL: "http://localhost:8080"})
+	if err == nil {
+		t.Error("Expected error when sender number is missing")
+	}
+
+	notifier, err := NewSignalNotifier(SignalConfig{ServerURL: "http://localhost:8080", Number: "+15550001111"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "signal" {
+		t.Errorf("Expected name 'signal', got '%s'", notifier.Name())
+	}
+}
+
+func TestSignalSendWithAttachment(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/graph.png":
+			_, _ = w.Write([]byte("\x89PNG\r\n\x1a\n"))
+		case "/v2/send":
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("Failed to decode body: %v", err)
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"timestamp":"1700000000000"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewSignalNotifier(SignalConfig{ServerURL: server.URL, Number: "+15550001111"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	timestamp, err := notifier.Post(context.Background(), &Message{
+		Title:   "Backup",
+		Text:    "completed",
+		Channel: "+15550002222, group.abc123",
+		Attachments: []Attachment{
+			{ImageURL: server.URL + "/graph.png"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+
+	if timestamp != "1700000000000" {
+		t.Errorf("Expected timestamp 1700000000000, got %s", timestamp)
+	}
+
+	if body["number"] != "+15550001111" || body["message"] != "**Backup**\ncompleted" {
+		t.Errorf("Unexpected payload: %v", body)
+	}
+
+	recipients := body["recipients"].([]interface{})
+	if len(recipients) != 2 || recipients[1] != "group.abc123" {
+		t.Errorf("Unexpected recipients: %v", recipients)
+	}
+
+	attachments := body["base64_attachments"].([]interface{})
+	if len(attachments) != 1 || !strings.HasPrefix(attachments[0].(string), "data:image/png;filename=graph.png;base64,") {
+		t.Errorf("Unexpected attachments: %v", attachments)
+	}
+}
+
+func TestSignalRequiresRecipient(t *testing.T) {
+	notifier, err := NewSignalNotifier(SignalConfig{ServerURL: "http://localhost:8080", Number: "+15550001111"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if err := notifier.Send(context.Background(), "hello"); err == nil {
+		t.Error("Expected error when no recipient is configured")
+	}
+}
@@ -2,21 +2,47 @@ package notify
 
 import (
 	"context"
-	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/slack-go/slack"
 )
 
 // SlackNotifier sends notifications via Slack API
 type SlackNotifier struct {
+	name           string
 	client         *slack.Client
+	httpClient     *http.Client
+	token          string
+	apiURL         string
 	defaultChannel string
 	username       string
 	iconEmoji      string
+
+	channelCacheTTL    time.Duration
+	autoJoinChannels   bool
+	strictChannels     bool
+	threadLongMessages bool
+	snippetThreshold   int
+
+	mu              sync.Mutex
+	userIDs         map[string]string // email -> user ID
+	dmChannels      map[string]string // user ID -> DM channel ID
+	channels        map[string]slackChannel
+	channelsFetched time.Time
+	channelsTried   time.Time
+	channelsRefresh *slackChannelRefresh
 }
 
 // SlackConfig holds configuration for Slack notifications
 type SlackConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "slack")
+	Name string
+
 	// Token is the Slack Bot or User OAuth token
 	Token string
 
@@ -31,6 +57,29 @@ type SlackConfig struct {
 
 	// WebhookURL for incoming webhooks (alternative to Token)
 	WebhookURL string
+
+	// APIURL overrides the Slack Web API base URL (optional, for testing; must end with a slash)
+	APIURL string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+
+	// ChannelCacheTTL is how long channel name to ID lookups are cached (optional, default: 10m)
+	ChannelCacheTTL time.Duration
+
+	// AutoJoinChannels joins public channels the bot is not a member of (optional, needs channels:join)
+	AutoJoinChannels bool
+
+	// StrictChannels returns an error for channel names that can't be resolved or that the bot
+	// isn't a member of, instead of passing them to Slack as given (optional)
+	StrictChannels bool
+
+	// ThreadLongMessages posts every part of a split message after the first as a thread reply (optional)
+	ThreadLongMessages bool
+
+	// SnippetThreshold uploads text longer than this many characters as a snippet in the
+	// message thread instead of splitting it (optional, 0 disables)
+	SnippetThreshold int
 }
 
 // NewSlackNotifier creates a new Slack notifier
@@ -42,25 +91,51 @@ func NewSlackNotifier(config *SlackConfig) (*SlackNotifier, error) {
 		}
 	}
 
+	apiURL := config.APIURL
+	if apiURL == "" {
+		apiURL = slack.APIURL
+	}
+	httpClient := newHTTPClient(config.HTTPClient)
+
+	channelCacheTTL := config.ChannelCacheTTL
+	if channelCacheTTL == 0 {
+		channelCacheTTL = defaultSlackChannelCacheTTL
+	}
+
 	var client *slack.Client
 	if config.Token != "" {
-		client = slack.New(config.Token)
+		client = slack.New(config.Token, slack.OptionAPIURL(apiURL), slack.OptionHTTPClient(httpClient))
 	} else {
 		// For webhook, we'll handle it differently in Send methods
 		client = nil
 	}
 
+	name := config.Name
+	if name == "" {
+		name = "slack"
+	}
+
 	return &SlackNotifier{
+		name:           name,
 		client:         client,
+		httpClient:     httpClient,
+		token:          config.Token,
+		apiURL:         apiURL,
 		defaultChannel: config.DefaultChannel,
 		username:       config.Username,
 		iconEmoji:      config.IconEmoji,
+
+		channelCacheTTL:    channelCacheTTL,
+		autoJoinChannels:   config.AutoJoinChannels,
+		strictChannels:     config.StrictChannels,
+		threadLongMessages: config.ThreadLongMessages,
+		snippetThreshold:   config.SnippetThreshold,
 	}, nil
 }
 
 // Name returns the name of the provider
 func (s *SlackNotifier) Name() string {
-	return "slack"
+	return s.name
 }
 
 // Send sends a simple text message
@@ -73,15 +148,22 @@ func (s *SlackNotifier) Send(ctx context.Context, message string) error {
 
 // SendWithOptions sends a message with additional options
 func (s *SlackNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	_, err := s.Post(ctx, msg)
+	return err
+}
+
+// Post sends a message and returns a receipt referencing it, for use with
+// reactions, pins and thread replies. For split messages the receipt refers to the first part.
+func (s *SlackNotifier) Post(ctx context.Context, msg *Message) (*SlackReceipt, error) {
 	if s.client == nil {
-		return &NotificationError{
+		return nil, &NotificationError{
 			Provider: "slack",
 			Message:  "slack client not initialized (webhook support not yet implemented for SendWithOptions)",
 		}
 	}
 
 	if msg.Text == "" {
-		return &NotificationError{
+		return nil, &NotificationError{
 			Provider: "slack",
 			Message:  "message text is required",
 		}
@@ -93,25 +175,43 @@ func (s *SlackNotifier) SendWithOptions(ctx context.Context, msg *Message) error
 	}
 
 	if channel == "" {
-		return &NotificationError{
+		return nil, &NotificationError{
 			Provider: "slack",
 			Message:  "channel is required",
 		}
 	}
 
-	// Build message options
-	options := []slack.MsgOption{
-		slack.MsgOptionText(msg.Text, false),
+	channel, err := s.ResolveChannel(ctx, channel)
+	if err != nil {
+		return nil, err
 	}
 
-	if s.username != "" {
-		options = append(options, slack.MsgOptionUsername(s.username))
+	// Text that doesn't fit in one section block is split or uploaded as a snippet
+	if chunks := splitSlackText(msg.Text, slackMaxTextLength); len(chunks) > 1 {
+		if s.snippetThreshold > 0 && utf8.RuneCountInString(msg.Text) > s.snippetThreshold {
+			return s.sendSnippet(ctx, channel, msg, chunks)
+		}
+		return s.sendLongMessage(ctx, channel, msg, chunks)
 	}
 
-	if s.iconEmoji != "" {
-		options = append(options, slack.MsgOptionIconEmoji(s.iconEmoji))
+	channelID, ts, err := s.client.PostMessageContext(ctx, channel, s.messageOptions(msg)...)
+	if err != nil {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "failed to send message",
+			Err:      err,
+		}
 	}
 
+	return &SlackReceipt{Channel: channelID, Timestamp: ts}, nil
+}
+
+// messageOptions builds the Slack message options for msg
+func (s *SlackNotifier) messageOptions(msg *Message) []slack.MsgOption {
+	options := append([]slack.MsgOption{
+		slack.MsgOptionText(msg.Text, false),
+	}, s.identityOptions()...)
+
 	// Add attachments if present
 	if len(msg.Attachments) > 0 {
 		slackAttachments := s.convertAttachments(msg.Attachments)
@@ -134,16 +234,22 @@ func (s *SlackNotifier) SendWithOptions(ctx context.Context, msg *Message) error
 		options = options[1:]
 	}
 
-	_, _, err := s.client.PostMessageContext(ctx, channel, options...)
-	if err != nil {
-		return &NotificationError{
-			Provider: "slack",
-			Message:  "failed to send message",
-			Err:      err,
-		}
+	return options
+}
+
+// identityOptions returns the username and icon options
+func (s *SlackNotifier) identityOptions() []slack.MsgOption {
+	var options []slack.MsgOption
+
+	if s.username != "" {
+		options = append(options, slack.MsgOptionUsername(s.username))
 	}
 
-	return nil
+	if s.iconEmoji != "" {
+		options = append(options, slack.MsgOptionIconEmoji(s.iconEmoji))
+	}
+
+	return options
 }
 
 // SendRichMessage sends a message with blocks for rich formatting
@@ -160,15 +266,29 @@ func (s *SlackNotifier) SendRichMessage(ctx context.Context, channel string, blo
 	}
 
 	// Convert interface{} to []slack.Block
-	slackBlocks, ok := blocks.([]slack.Block)
-	if !ok {
+	var slackBlocks []slack.Block
+	switch b := blocks.(type) {
+	case []slack.Block:
+		slackBlocks = b
+	case *SlackBlocks:
+		built, err := b.Build()
+		if err != nil {
+			return err
+		}
+		slackBlocks = built
+	default:
 		return &NotificationError{
 			Provider: "slack",
-			Message:  "blocks must be of type []slack.Block",
+			Message:  "blocks must be of type []slack.Block or *SlackBlocks",
 		}
 	}
 
-	_, _, err := s.client.PostMessageContext(
+	channel, err := s.ResolveChannel(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.client.PostMessageContext(
 		ctx,
 		channel,
 		slack.MsgOptionBlocks(slackBlocks...),
@@ -221,34 +341,24 @@ func (s *SlackNotifier) GetClient() *slack.Client {
 	return s.client
 }
 
-// SendFile uploads a file to Slack
+// SendFile uploads a file from disk to Slack.
+// Use UploadFiles for in-memory content, multiple files or threads.
 func (s *SlackNotifier) SendFile(ctx context.Context, channel, filePath, title, comment string) error {
-	if s.client == nil {
-		return &NotificationError{
-			Provider: "slack",
-			Message:  "slack client not initialized",
-		}
-	}
-
-	if channel == "" {
-		channel = s.defaultChannel
-	}
-
-	params := slack.FileUploadParameters{
-		File:           filePath,
-		Channels:       []string{channel},
-		Title:          title,
-		InitialComment: comment,
-	}
-
-	_, err := s.client.UploadFileContext(ctx, params)
+	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return &NotificationError{
 			Provider: "slack",
-			Message:  fmt.Sprintf("failed to upload file: %v", err),
+			Message:  "failed to read file",
 			Err:      err,
 		}
 	}
 
-	return nil
+	_, err = s.UploadFiles(ctx, &SlackUpload{
+		Channel: channel,
+		Comment: comment,
+		Files: []SlackFile{
+			{Filename: filepath.Base(filePath), Title: title, Content: content},
+		},
+	})
+	return err
 }
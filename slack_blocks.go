@@ -0,0 +1,203 @@
+package notify
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+// Slack Block Kit limits checked by SlackBlocks
+const (
+	slackMaxBlocks          = 50
+	slackMaxTextLength      = 3000
+	slackMaxFields          = 10
+	slackMaxFieldLength     = 2000
+	slackMaxHeaderLength    = 150
+	slackMaxContextElements = 10
+	slackMaxActionElements  = 25
+	slackMaxButtonText      = 75
+	slackMaxButtonValue     = 2000
+	slackMaxActionIDLength  = 255
+	slackMaxURLLength       = 3000
+	slackMaxAltTextLength   = 2000
+)
+
+// SlackButton is a button in an actions block
+type SlackButton struct {
+	// ActionID identifies the button in interaction payloads (see SlackInteractionHandler.OnAction)
+	ActionID string
+
+	// Text is the button label
+	Text string
+
+	// Value is sent with the interaction payload (optional)
+	Value string
+
+	// URL opens a link when the button is clicked (optional)
+	URL string
+
+	// Style is "primary", "danger" or empty for the default style
+	Style string
+}
+
+// SlackBlocks is a fluent builder for Block Kit messages.
+// Limits are checked as blocks are added; Build returns every problem found.
+type SlackBlocks struct {
+	blocks []slack.Block
+	errs   []string
+}
+
+// NewSlackBlocks creates an empty Block Kit builder
+func NewSlackBlocks() *SlackBlocks {
+	return &SlackBlocks{}
+}
+
+// Header adds a header block with plain text
+func (b *SlackBlocks) Header(text string) *SlackBlocks {
+	b.checkRequired("header", "text", text)
+	b.checkLength("header", "text", text, slackMaxHeaderLength)
+
+	return b.add(slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, text, false, false)))
+}
+
+// Section adds a section block with mrkdwn text and optional fields (shown in two columns)
+func (b *SlackBlocks) Section(text string, fields ...string) *SlackBlocks {
+	if text == "" && len(fields) == 0 {
+		b.errorf("section", "text or fields are required")
+	}
+	b.checkLength("section", "text", text, slackMaxTextLength)
+
+	if len(fields) > slackMaxFields {
+		b.errorf("section", "%d fields, limit %d", len(fields), slackMaxFields)
+	}
+
+	var textObject *slack.TextBlockObject
+	if text != "" {
+		textObject = slack.NewTextBlockObject(slack.MarkdownType, text, false, false)
+	}
+
+	var fieldObjects []*slack.TextBlockObject
+	for i, field := range fields {
+		b.checkLength("section", fmt.Sprintf("field %d", i+1), field, slackMaxFieldLength)
+		fieldObjects = append(fieldObjects, slack.NewTextBlockObject(slack.MarkdownType, field, false, false))
+	}
+
+	return b.add(slack.NewSectionBlock(textObject, fieldObjects, nil))
+}
+
+// Context adds a context block with small mrkdwn text elements
+func (b *SlackBlocks) Context(elements ...string) *SlackBlocks {
+	if len(elements) == 0 {
+		b.errorf("context", "at least one element is required")
+	}
+	if len(elements) > slackMaxContextElements {
+		b.errorf("context", "%d elements, limit %d", len(elements), slackMaxContextElements)
+	}
+
+	mixedElements := make([]slack.MixedElement, len(elements))
+	for i, element := range elements {
+		b.checkLength("context", fmt.Sprintf("element %d", i+1), element, slackMaxTextLength)
+		mixedElements[i] = slack.NewTextBlockObject(slack.MarkdownType, element, false, false)
+	}
+
+	return b.add(slack.NewContextBlock("", mixedElements...))
+}
+
+// Divider adds a divider block
+func (b *SlackBlocks) Divider() *SlackBlocks {
+	return b.add(slack.NewDividerBlock())
+}
+
+// Actions adds an actions block with buttons
+func (b *SlackBlocks) Actions(buttons ...SlackButton) *SlackBlocks {
+	if len(buttons) == 0 {
+		b.errorf("actions", "at least one button is required")
+	}
+	if len(buttons) > slackMaxActionElements {
+		b.errorf("actions", "%d elements, limit %d", len(buttons), slackMaxActionElements)
+	}
+
+	elements := make([]slack.BlockElement, len(buttons))
+	for i, button := range buttons {
+		name := fmt.Sprintf("button %d", i+1)
+		b.checkRequired("actions", name+" text", button.Text)
+		b.checkLength("actions", name+" text", button.Text, slackMaxButtonText)
+		b.checkLength("actions", name+" action ID", button.ActionID, slackMaxActionIDLength)
+		b.checkLength("actions", name+" value", button.Value, slackMaxButtonValue)
+		b.checkLength("actions", name+" URL", button.URL, slackMaxURLLength)
+		if button.Style != "" && button.Style != string(slack.StylePrimary) && button.Style != string(slack.StyleDanger) {
+			b.errorf("actions", "%s style must be %q or %q, got %q", name, slack.StylePrimary, slack.StyleDanger, button.Style)
+		}
+
+		element := slack.NewButtonBlockElement(button.ActionID, button.Value,
+			slack.NewTextBlockObject(slack.PlainTextType, button.Text, false, false))
+		element.URL = button.URL
+		element.Style = slack.Style(button.Style)
+		elements[i] = element
+	}
+
+	return b.add(slack.NewActionBlock("", elements...))
+}
+
+// Image adds an image block; title is optional
+func (b *SlackBlocks) Image(imageURL, altText, title string) *SlackBlocks {
+	b.checkRequired("image", "URL", imageURL)
+	b.checkRequired("image", "alt text", altText)
+	b.checkLength("image", "URL", imageURL, slackMaxURLLength)
+	b.checkLength("image", "alt text", altText, slackMaxAltTextLength)
+	b.checkLength("image", "title", title, slackMaxAltTextLength)
+
+	var titleObject *slack.TextBlockObject
+	if title != "" {
+		titleObject = slack.NewTextBlockObject(slack.PlainTextType, title, false, false)
+	}
+
+	return b.add(slack.NewImageBlock(imageURL, altText, "", titleObject))
+}
+
+// Build returns the blocks, or a NotificationError describing every limit that was exceeded
+func (b *SlackBlocks) Build() ([]slack.Block, error) {
+	errs := b.errs
+	if len(b.blocks) == 0 {
+		errs = append(errs, "at least one block is required")
+	}
+	if len(b.blocks) > slackMaxBlocks {
+		errs = append(errs, fmt.Sprintf("%d blocks, limit %d", len(b.blocks), slackMaxBlocks))
+	}
+
+	if len(errs) > 0 {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "invalid blocks: " + strings.Join(errs, "; "),
+		}
+	}
+
+	return b.blocks, nil
+}
+
+// add appends a block
+func (b *SlackBlocks) add(block slack.Block) *SlackBlocks {
+	b.blocks = append(b.blocks, block)
+	return b
+}
+
+// errorf records a problem with the block being added
+func (b *SlackBlocks) errorf(blockType, format string, args ...interface{}) {
+	b.errs = append(b.errs, fmt.Sprintf("block %d (%s): %s", len(b.blocks)+1, blockType, fmt.Sprintf(format, args...)))
+}
+
+// checkRequired records an error when value is empty
+func (b *SlackBlocks) checkRequired(blockType, name, value string) {
+	if value == "" {
+		b.errorf(blockType, "%s is required", name)
+	}
+}
+
+// checkLength records an error when value has more than limit characters
+func (b *SlackBlocks) checkLength(blockType, name, value string, limit int) {
+	if length := utf8.RuneCountInString(value); length > limit {
+		b.errorf(blockType, "%s is %d characters, limit %d", name, length, limit)
+	}
+}
@@ -0,0 +1,110 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestSlackBlocksBuild(t *testing.T) {
+	blocks, err := NewSlackBlocks().
+		Header("Deployment finished").
+		Section("*api* was deployed to production", "*Version*\nv1.4.2", "*Duration*\n3m").
+		Divider().
+		Image("https://example.com/graph.png", "Latency graph", "Latency").
+		Context("Triggered by <@U1>").
+		Actions(
+			SlackButton{ActionID: "rollback", Text: "Roll back", Value: "v1.4.1", Style: "danger"},
+			SlackButton{ActionID: "logs", Text: "Logs", URL: "https://example.com/logs"},
+		).
+		Build()
+	if err != nil {
+		t.Fatalf("Build failed: %v", err)
+	}
+
+	types := make([]string, len(blocks))
+	for i, block := range blocks {
+		types[i] = string(block.BlockType())
+	}
+	if strings.Join(types, ",") != "header,section,divider,image,context,actions" {
+		t.Errorf("Unexpected block types %v", types)
+	}
+
+	section := blocks[1].(*slack.SectionBlock)
+	if section.Text.Type != slack.MarkdownType || len(section.Fields) != 2 {
+		t.Errorf("Unexpected section %+v", section)
+	}
+
+	button := blocks[5].(*slack.ActionBlock).Elements.ElementSet[0].(*slack.ButtonBlockElement)
+	if button.ActionID != "rollback" || button.Value != "v1.4.1" || button.Style != slack.StyleDanger {
+		t.Errorf("Unexpected button %+v", button)
+	}
+}
+
+func TestSlackBlocksLimits(t *testing.T) {
+	fields := make([]string, 11)
+	for i := range fields {
+		fields[i] = "field"
+	}
+
+	_, err := NewSlackBlocks().
+		Header(strings.Repeat("h", 151)).
+		Section(strings.Repeat("é", 3001), fields...).
+		Actions(SlackButton{ActionID: "ack", Style: "warning"}).
+		Build()
+	if err == nil {
+		t.Fatal("Expected validation error")
+	}
+
+	for _, want := range []string{
+		"block 1 (header): text is 151 characters, limit 150",
+		"block 2 (section): text is 3001 characters, limit 3000",
+		"block 2 (section): 11 fields, limit 10",
+		"block 3 (actions): button 1 text is required",
+		`block 3 (actions): button 1 style must be "primary" or "danger", got "warning"`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected %q in error, got %v", want, err)
+		}
+	}
+
+	builder := NewSlackBlocks()
+	for i := 0; i < 51; i++ {
+		builder.Divider()
+	}
+	if _, err := builder.Build(); err == nil || !strings.Contains(err.Error(), "51 blocks, limit 50") {
+		t.Errorf("Expected block count error, got %v", err)
+	}
+}
+
+func TestSlackSendRichMessageBuilder(t *testing.T) {
+	var blocks []map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.Unmarshal([]byte(r.FormValue("blocks")), &blocks); err != nil {
+			t.Errorf("Failed to decode blocks: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"channel":"C1","ts":"1.0"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(&SlackConfig{Token: "xoxb-test", DefaultChannel: "C1", APIURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if err := notifier.SendRichMessage(context.Background(), "", NewSlackBlocks().Header("Hello").Divider()); err != nil {
+		t.Fatalf("SendRichMessage failed: %v", err)
+	}
+	if len(blocks) != 2 || blocks[0]["type"] != "header" {
+		t.Errorf("Unexpected blocks %v", blocks)
+	}
+
+	if err := notifier.SendRichMessage(context.Background(), "", NewSlackBlocks().Header("")); err == nil {
+		t.Error("Expected validation error before sending")
+	}
+}
@@ -0,0 +1,217 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+const (
+	// defaultSlackChannelCacheTTL is how long resolved channel names are cached
+	defaultSlackChannelCacheTTL = 10 * time.Minute
+
+	// slackChannelMissRefreshInterval is the minimum time between cache refreshes caused by
+	// unknown names or failed lookups, so a misspelled channel or a missing scope doesn't
+	// list the workspace on every send
+	slackChannelMissRefreshInterval = time.Minute
+)
+
+// slackChannel is a cached conversations.list entry
+type slackChannel struct {
+	id       string
+	private  bool
+	isMember bool
+}
+
+// slackChannelRefresh is an in-flight conversations.list refresh shared by concurrent callers
+type slackChannelRefresh struct {
+	done chan struct{}
+	err  error
+}
+
+// ResolveChannel returns the ID of a channel given as "#name", "name" or an ID.
+// Names are resolved with conversations.list (needs channels:read and groups:read) and
+// cached for ChannelCacheTTL; unknown names trigger a refresh at most once a minute.
+// Public channels the bot isn't a member of are joined when AutoJoinChannels is set.
+// Names that can't be resolved are returned unchanged for chat.postMessage to handle,
+// unless StrictChannels is set, in which case an error is returned for names that can't
+// be resolved and for channels the bot isn't a member of.
+// "@username" targets and IDs, including user IDs for direct messages, are returned unchanged.
+func (s *SlackNotifier) ResolveChannel(ctx context.Context, channel string) (string, error) {
+	if s.client == nil {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  "slack client not initialized",
+		}
+	}
+
+	if channel == "" || strings.HasPrefix(channel, "@") || isSlackChannelID(channel) {
+		return channel, nil
+	}
+
+	name := strings.ToLower(strings.TrimPrefix(channel, "#"))
+
+	s.mu.Lock()
+	entry, ok := s.channels[name]
+	stale := time.Since(s.channelsFetched) >= s.channelCacheTTL
+	canRefresh := time.Since(s.channelsTried) >= minDuration(s.channelCacheTTL, slackChannelMissRefreshInterval)
+	s.mu.Unlock()
+
+	// Unknown names may be new channels, but refresh for them at most once per interval
+	if (stale || !ok) && canRefresh {
+		if err := s.refreshChannels(ctx); err != nil {
+			if s.strictChannels {
+				return "", err
+			}
+		}
+
+		s.mu.Lock()
+		entry, ok = s.channels[name]
+		s.mu.Unlock()
+	}
+
+	if !ok {
+		if !s.strictChannels {
+			return channel, nil
+		}
+
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  fmt.Sprintf("channel #%s not found (archived channels are ignored)", name),
+		}
+	}
+
+	if entry.isMember {
+		return entry.id, nil
+	}
+
+	if !s.autoJoinChannels || entry.private {
+		if !s.strictChannels {
+			// Public channels accept posts from non-members with chat:write.public
+			return entry.id, nil
+		}
+
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  fmt.Sprintf("bot is not a member of #%s; invite it with /invite", name),
+		}
+	}
+
+	if _, _, _, err := s.client.JoinConversationContext(ctx, entry.id); err != nil {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  fmt.Sprintf("failed to join #%s", name),
+			Err:      err,
+		}
+	}
+
+	s.mu.Lock()
+	entry.isMember = true
+	if s.channels != nil {
+		s.channels[name] = entry
+	}
+	s.mu.Unlock()
+
+	return entry.id, nil
+}
+
+// refreshChannels reloads the channel name cache from conversations.list.
+// Concurrent callers wait for the refresh already in flight instead of starting another.
+func (s *SlackNotifier) refreshChannels(ctx context.Context) error {
+	s.mu.Lock()
+	if refresh := s.channelsRefresh; refresh != nil {
+		s.mu.Unlock()
+
+		select {
+		case <-refresh.done:
+			return refresh.err
+		case <-ctx.Done():
+			return &NotificationError{
+				Provider: "slack",
+				Message:  "context done",
+				Err:      ctx.Err(),
+			}
+		}
+	}
+
+	refresh := &slackChannelRefresh{done: make(chan struct{})}
+	s.channelsRefresh = refresh
+	s.channelsTried = time.Now()
+	s.mu.Unlock()
+
+	channels, err := s.listChannels(ctx)
+
+	s.mu.Lock()
+	if err == nil {
+		s.channels = channels
+		s.channelsFetched = time.Now()
+	}
+	s.channelsRefresh = nil
+	s.mu.Unlock()
+
+	refresh.err = err
+	close(refresh.done)
+
+	return err
+}
+
+// listChannels fetches every unarchived public and private channel visible to the bot
+func (s *SlackNotifier) listChannels(ctx context.Context) (map[string]slackChannel, error) {
+	channels := make(map[string]slackChannel)
+	params := &slack.GetConversationsParameters{
+		ExcludeArchived: true,
+		Limit:           1000,
+		Types:           []string{"public_channel", "private_channel"},
+	}
+
+	for {
+		page, cursor, err := s.client.GetConversationsContext(ctx, params)
+		if err != nil {
+			return nil, &NotificationError{
+				Provider: "slack",
+				Message:  "failed to list channels",
+				Err:      err,
+			}
+		}
+
+		for _, channel := range page {
+			channels[channel.Name] = slackChannel{
+				id:       channel.ID,
+				private:  channel.IsPrivate,
+				isMember: channel.IsMember,
+			}
+		}
+
+		if cursor == "" {
+			return channels, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// minDuration returns the shorter of a and b
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// isSlackChannelID reports whether channel looks like a Slack ID (e.g., C0123456789 or U0123456789).
+// Channel names are always lowercase, so an uppercase alphanumeric value is an ID.
+func isSlackChannelID(channel string) bool {
+	if len(channel) < 2 || channel[0] < 'A' || channel[0] > 'Z' {
+		return false
+	}
+
+	for _, r := range channel[1:] {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	return true
+}
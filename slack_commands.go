@@ -0,0 +1,189 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// SlackCommandConfig holds configuration for SlackCommandHandler
+type SlackCommandConfig struct {
+	// SigningSecret is the app's signing secret used to verify requests
+	SigningSecret string
+
+	// HTTPClient is used to post delayed responses (optional)
+	HTTPClient *http.Client
+}
+
+// SlackCommandFunc handles a slash subcommand.
+// The returned message is sent as the immediate response; return nil to send nothing
+// and respond later with SlackCommand.Respond.
+type SlackCommandFunc func(ctx context.Context, cmd *SlackCommand) (*SlackResponseMessage, error)
+
+// SlackCommand is a slash command invocation, e.g., "/notify mute 1h"
+type SlackCommand struct {
+	// Command is the slash command, e.g., "/notify"
+	Command string
+
+	// Subcommand is the first word of Text, lowercased, e.g., "mute"
+	Subcommand string
+
+	// Args are the remaining words of Text, e.g., ["1h"]
+	Args []string
+
+	// Text is everything after the command
+	Text string
+
+	UserID      string
+	UserName    string
+	ChannelID   string
+	ChannelName string
+	TeamID      string
+	TriggerID   string
+	ResponseURL string
+
+	httpClient *http.Client
+}
+
+// Respond posts a delayed response to the command's response URL.
+// Slack accepts up to 5 responses within 30 minutes of the command.
+func (c *SlackCommand) Respond(ctx context.Context, msg *SlackResponseMessage) error {
+	return respondSlack(ctx, c.httpClient, c.ResponseURL, msg)
+}
+
+// SlackCommandHandler is an http.Handler for Slack slash commands.
+// It verifies request signatures and routes subcommands to registered handlers.
+// Slack expects a response within 3 seconds, so slow handlers should return nil
+// and respond from a goroutine with SlackCommand.Respond.
+type SlackCommandHandler struct {
+	signingSecret string
+	httpClient    *http.Client
+
+	mu             sync.RWMutex
+	subcommands    map[string]SlackCommandFunc
+	defaultHandler SlackCommandFunc
+}
+
+// NewSlackCommandHandler creates a new Slack slash command handler
+func NewSlackCommandHandler(config SlackCommandConfig) (*SlackCommandHandler, error) {
+	if config.SigningSecret == "" {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "signing secret is required",
+		}
+	}
+
+	return &SlackCommandHandler{
+		signingSecret: config.SigningSecret,
+		httpClient:    newHTTPClient(config.HTTPClient),
+		subcommands:   make(map[string]SlackCommandFunc),
+	}, nil
+}
+
+// Handle registers a handler for a subcommand, e.g., "status" for "/notify status"
+func (h *SlackCommandHandler) Handle(subcommand string, fn SlackCommandFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subcommands[strings.ToLower(subcommand)] = fn
+}
+
+// HandleDefault registers a handler for commands without a registered subcommand.
+// Without it, such commands get an ephemeral list of the available subcommands.
+func (h *SlackCommandHandler) HandleDefault(fn SlackCommandFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.defaultHandler = fn
+}
+
+// ServeHTTP verifies and handles a slash command request
+func (h *SlackCommandHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := verifySlackRequest(w, r, h.signingSecret)
+	if err != nil {
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid form body", http.StatusBadRequest)
+		return
+	}
+
+	response := h.dispatch(r.Context(), parseSlackCommand(form))
+	if response == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// dispatch routes cmd to its handler and returns the immediate response, if any.
+// Handler errors are reported to the user as an ephemeral message.
+func (h *SlackCommandHandler) dispatch(ctx context.Context, cmd *SlackCommand) *SlackResponseMessage {
+	cmd.httpClient = h.httpClient
+
+	h.mu.RLock()
+	fn, ok := h.subcommands[cmd.Subcommand]
+	if !ok {
+		fn = h.defaultHandler
+	}
+	h.mu.RUnlock()
+
+	if fn == nil {
+		return &SlackResponseMessage{Text: h.usage(cmd)}
+	}
+
+	response, err := fn(ctx, cmd)
+	if err != nil {
+		return &SlackResponseMessage{Text: fmt.Sprintf("%s %s failed: %v", cmd.Command, cmd.Subcommand, err)}
+	}
+
+	return response
+}
+
+// usage lists the registered subcommands
+func (h *SlackCommandHandler) usage(cmd *SlackCommand) string {
+	h.mu.RLock()
+	names := make([]string, 0, len(h.subcommands))
+	for name := range h.subcommands {
+		names = append(names, "`"+cmd.Command+" "+name+"`")
+	}
+	h.mu.RUnlock()
+	sort.Strings(names)
+
+	usage := "Available commands: " + strings.Join(names, ", ")
+	if cmd.Subcommand != "" {
+		usage = fmt.Sprintf("Unknown command `%s %s`. %s", cmd.Command, cmd.Subcommand, usage)
+	}
+	return usage
+}
+
+// parseSlackCommand builds a SlackCommand from slash command fields,
+// splitting the text into a subcommand and arguments
+func parseSlackCommand(form url.Values) *SlackCommand {
+	cmd := &SlackCommand{
+		Command:     form.Get("command"),
+		Text:        form.Get("text"),
+		UserID:      form.Get("user_id"),
+		UserName:    form.Get("user_name"),
+		ChannelID:   form.Get("channel_id"),
+		ChannelName: form.Get("channel_name"),
+		TeamID:      form.Get("team_id"),
+		TriggerID:   form.Get("trigger_id"),
+		ResponseURL: form.Get("response_url"),
+	}
+
+	words := strings.Fields(cmd.Text)
+	if len(words) > 0 {
+		cmd.Subcommand = strings.ToLower(words[0])
+		cmd.Args = words[1:]
+	}
+
+	return cmd
+}
@@ -0,0 +1,92 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSlackCommandRouting(t *testing.T) {
+	delayed := make(chan SlackResponseMessage, 1)
+	responseServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var msg SlackResponseMessage
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			t.Errorf("Failed to decode response: %v", err)
+		}
+		delayed <- msg
+	}))
+	defer responseServer.Close()
+
+	handler, err := NewSlackCommandHandler(SlackCommandConfig{SigningSecret: "secret"})
+	if err != nil {
+		t.Fatalf("Failed to create handler: %v", err)
+	}
+
+	handler.Handle("status", func(ctx context.Context, cmd *SlackCommand) (*SlackResponseMessage, error) {
+		return &SlackResponseMessage{Text: "All systems operational", ResponseType: "in_channel"}, nil
+	})
+	handler.Handle("mute", func(ctx context.Context, cmd *SlackCommand) (*SlackResponseMessage, error) {
+		if len(cmd.Args) != 1 || cmd.Args[0] != "1h" || cmd.UserID != "U1" {
+			t.Errorf("Unexpected command %+v", cmd)
+		}
+		if err := cmd.Respond(ctx, &SlackResponseMessage{Text: "Muted for 1h"}); err != nil {
+			t.Errorf("Respond failed: %v", err)
+		}
+		return nil, nil
+	})
+	handler.Handle("fail", func(ctx context.Context, cmd *SlackCommand) (*SlackResponseMessage, error) {
+		return nil, errors.New("boom")
+	})
+
+	run := func(text string) *httptest.ResponseRecorder {
+		body := url.Values{
+			"command":      {"/notify"},
+			"text":         {text},
+			"user_id":      {"U1"},
+			"response_url": {responseServer.URL},
+		}.Encode()
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, newSignedSlackRequest("secret", body))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+		}
+		return recorder
+	}
+
+	if body := run("Status").Body.String(); !strings.Contains(body, "All systems operational") || !strings.Contains(body, `"response_type":"in_channel"`) {
+		t.Errorf("Unexpected status response %s", body)
+	}
+
+	if body := run("mute 1h").Body.String(); body != "" {
+		t.Errorf("Expected empty immediate response, got %s", body)
+	}
+	if msg := <-delayed; msg.Text != "Muted for 1h" {
+		t.Errorf("Unexpected delayed response %+v", msg)
+	}
+
+	if body := run("fail").Body.String(); !strings.Contains(body, "/notify fail failed: boom") {
+		t.Errorf("Unexpected error response %s", body)
+	}
+
+	if body := run("bogus").Body.String(); !strings.Contains(body, "Unknown command `/notify bogus`") || !strings.Contains(body, "`/notify mute`, `/notify status`") {
+		t.Errorf("Unexpected usage response %s", body)
+	}
+}
+
+func TestSlackCommandSignature(t *testing.T) {
+	handler, err := NewSlackCommandHandler(SlackCommandConfig{SigningSecret: "secret"})
+	if err != nil {
+		t.Fatalf("Failed to create handler: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newSignedSlackRequest("wrong", "command=%2Fnotify&text=status"))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,246 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+
+	"github.com/slack-go/slack"
+)
+
+// SlackFile is a file uploaded with UploadFiles
+type SlackFile struct {
+	// Filename is the name of the file, including its extension
+	Filename string
+
+	// Title is shown above the file (optional, defaults to Filename)
+	Title string
+
+	// ContentType is the MIME type (optional, detected from Filename or content)
+	ContentType string
+
+	// AltText describes an image for screen readers (optional)
+	AltText string
+
+	// SnippetType sets syntax highlighting for text snippets (optional, e.g., "go" or "json")
+	SnippetType string
+
+	// Content is the file content
+	Content []byte
+
+	// Reader is read to the end when Content is nil
+	Reader io.Reader
+}
+
+// SlackUpload describes files shared together in a single message
+type SlackUpload struct {
+	// Channel is the channel to share the files in (optional, defaults to DefaultChannel)
+	Channel string
+
+	// ThreadTS shares the files as a reply in a thread (optional)
+	ThreadTS string
+
+	// Comment is the message text posted with the files (optional)
+	Comment string
+
+	// Files to upload
+	Files []SlackFile
+}
+
+// slackResult is implemented by Slack Web API responses
+type slackResult interface {
+	Err() error
+}
+
+type slackUploadURLResponse struct {
+	slack.SlackResponse
+	UploadURL string `json:"upload_url"`
+	FileID    string `json:"file_id"`
+}
+
+type slackCompleteUploadResponse struct {
+	slack.SlackResponse
+	Files []slack.FileSummary `json:"files"`
+}
+
+// UploadFiles uploads one or more files using files.getUploadURLExternal and
+// files.completeUploadExternal, and shares them in a single message.
+// It returns the IDs of the uploaded files.
+func (s *SlackNotifier) UploadFiles(ctx context.Context, upload *SlackUpload) ([]string, error) {
+	if s.client == nil {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "slack client not initialized",
+		}
+	}
+
+	if len(upload.Files) == 0 {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "at least one file is required",
+		}
+	}
+
+	channel := upload.Channel
+	if channel == "" {
+		channel = s.defaultChannel
+	}
+	if channel == "" {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "channel is required",
+		}
+	}
+
+	channel, err := s.ResolveChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]slack.FileSummary, len(upload.Files))
+	for i, file := range upload.Files {
+		id, err := s.uploadFile(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+
+		title := file.Title
+		if title == "" {
+			title = file.Filename
+		}
+		summaries[i] = slack.FileSummary{ID: id, Title: title}
+	}
+
+	filesJSON, err := json.Marshal(summaries)
+	if err != nil {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "failed to marshal files",
+			Err:      err,
+		}
+	}
+
+	values := url.Values{
+		"files":      {string(filesJSON)},
+		"channel_id": {channel},
+	}
+	if upload.Comment != "" {
+		values.Set("initial_comment", upload.Comment)
+	}
+	if upload.ThreadTS != "" {
+		values.Set("thread_ts", upload.ThreadTS)
+	}
+
+	var result slackCompleteUploadResponse
+	if err := s.apiCall(ctx, "files.completeUploadExternal", values, &result); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(result.Files))
+	for i, file := range result.Files {
+		ids[i] = file.ID
+	}
+	return ids, nil
+}
+
+// uploadFile reserves an upload URL for file, sends its content and returns the file ID
+func (s *SlackNotifier) uploadFile(ctx context.Context, file SlackFile) (string, error) {
+	if file.Filename == "" {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  "file name is required",
+		}
+	}
+
+	content := file.Content
+	if content == nil && file.Reader != nil {
+		var err error
+		if content, err = io.ReadAll(file.Reader); err != nil {
+			return "", &NotificationError{
+				Provider: "slack",
+				Message:  fmt.Sprintf("failed to read %s", file.Filename),
+				Err:      err,
+			}
+		}
+	}
+	if len(content) == 0 {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  fmt.Sprintf("file %s is empty", file.Filename),
+		}
+	}
+
+	values := url.Values{
+		"filename": {file.Filename},
+		"length":   {strconv.Itoa(len(content))},
+	}
+	if file.AltText != "" {
+		values.Set("alt_txt", file.AltText)
+	}
+	if file.SnippetType != "" {
+		values.Set("snippet_type", file.SnippetType)
+	}
+
+	var reserved slackUploadURLResponse
+	if err := s.apiCall(ctx, "files.getUploadURLExternal", values, &reserved); err != nil {
+		return "", err
+	}
+
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(file.Filename))
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, reserved.UploadURL, bytes.NewReader(content))
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  "failed to create upload request",
+			Err:      err,
+		}
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	status, body, err := doRequest(s.httpClient, "slack", req)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  fmt.Sprintf("failed to upload %s: status %d: %s", file.Filename, status, string(body)),
+		}
+	}
+
+	return reserved.FileID, nil
+}
+
+// apiCall calls a Slack Web API method with form values and decodes the response into out
+func (s *SlackNotifier) apiCall(ctx context.Context, method string, values url.Values, out slackResult) error {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+s.token)
+
+	if err := sendForm(ctx, s.httpClient, "slack", s.apiURL+method, header, values, out); err != nil {
+		return err
+	}
+
+	if err := out.Err(); err != nil {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  method + " failed",
+			Err:      err,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,275 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/slack-go/slack"
+)
+
+// maxSlackRequestBody limits the size of requests accepted from Slack
+const maxSlackRequestBody = 1 << 20
+
+// SlackInteractionConfig holds configuration for SlackInteractionHandler
+type SlackInteractionConfig struct {
+	// SigningSecret is the app's signing secret used to verify requests
+	SigningSecret string
+
+	// HTTPClient is used to post to response URLs (optional)
+	HTTPClient *http.Client
+}
+
+// SlackActionFunc handles a block action, such as a button click
+type SlackActionFunc func(ctx context.Context, action *SlackAction) error
+
+// SlackViewFunc handles a modal submission.
+// A non-nil response is returned to Slack, e.g., to show validation errors or update the view.
+type SlackViewFunc func(ctx context.Context, submission *SlackViewSubmission) (*slack.ViewSubmissionResponse, error)
+
+// SlackAction is a block action received from Slack
+type SlackAction struct {
+	ActionID    string
+	BlockID     string
+	Value       string
+	UserID      string
+	ChannelID   string
+	MessageTS   string
+	TriggerID   string
+	ResponseURL string
+
+	// Action is the raw block action
+	Action *slack.BlockAction
+
+	// Interaction is the full interaction payload
+	Interaction *slack.InteractionCallback
+
+	httpClient *http.Client
+}
+
+// Respond posts a message to the action's response URL, e.g., to replace the original message
+func (a *SlackAction) Respond(ctx context.Context, msg *SlackResponseMessage) error {
+	return respondSlack(ctx, a.httpClient, a.ResponseURL, msg)
+}
+
+// SlackViewSubmission is a modal submission received from Slack
+type SlackViewSubmission struct {
+	CallbackID      string
+	UserID          string
+	PrivateMetadata string
+
+	// Values holds the submitted input values by block ID and action ID
+	Values map[string]map[string]slack.BlockAction
+
+	// Interaction is the full interaction payload
+	Interaction *slack.InteractionCallback
+}
+
+// SlackResponseMessage is a message posted to a Slack response URL
+type SlackResponseMessage struct {
+	// Text is the message text (used as fallback when Blocks are set)
+	Text string `json:"text,omitempty"`
+
+	// Blocks for rich formatting (optional)
+	Blocks []slack.Block `json:"blocks,omitempty"`
+
+	// ResponseType is "ephemeral" (default) or "in_channel"
+	ResponseType string `json:"response_type,omitempty"`
+
+	// ReplaceOriginal replaces the message the interaction came from
+	ReplaceOriginal bool `json:"replace_original,omitempty"`
+
+	// DeleteOriginal deletes the message the interaction came from
+	DeleteOriginal bool `json:"delete_original,omitempty"`
+
+	// ThreadTS posts the response in a thread (optional)
+	ThreadTS string `json:"thread_ts,omitempty"`
+}
+
+// SlackInteractionHandler is an http.Handler for Slack interactivity requests.
+// It verifies request signatures and dispatches block actions by action_id
+// and view submissions by callback_id. Slack expects a response within 3 seconds,
+// so slow work should be done in a goroutine, responding later via the response URL.
+type SlackInteractionHandler struct {
+	signingSecret string
+	httpClient    *http.Client
+
+	mu      sync.RWMutex
+	actions map[string]SlackActionFunc
+	views   map[string]SlackViewFunc
+}
+
+// NewSlackInteractionHandler creates a new Slack interactivity handler
+func NewSlackInteractionHandler(config SlackInteractionConfig) (*SlackInteractionHandler, error) {
+	if config.SigningSecret == "" {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "signing secret is required",
+		}
+	}
+
+	return &SlackInteractionHandler{
+		signingSecret: config.SigningSecret,
+		httpClient:    newHTTPClient(config.HTTPClient),
+		actions:       make(map[string]SlackActionFunc),
+		views:         make(map[string]SlackViewFunc),
+	}, nil
+}
+
+// OnAction registers a callback for block actions with the given action_id
+func (h *SlackInteractionHandler) OnAction(actionID string, fn SlackActionFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.actions[actionID] = fn
+}
+
+// OnViewSubmission registers a callback for modal submissions with the given callback_id
+func (h *SlackInteractionHandler) OnViewSubmission(callbackID string, fn SlackViewFunc) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.views[callbackID] = fn
+}
+
+// ServeHTTP verifies and handles an interactivity request
+func (h *SlackInteractionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := verifySlackRequest(w, r, h.signingSecret)
+	if err != nil {
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid form body", http.StatusBadRequest)
+		return
+	}
+
+	var interaction slack.InteractionCallback
+	if err := json.Unmarshal([]byte(form.Get("payload")), &interaction); err != nil {
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.dispatch(r.Context(), &interaction)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if response == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// dispatch runs the callbacks for an interaction and returns the response body for Slack, if any
+func (h *SlackInteractionHandler) dispatch(ctx context.Context, interaction *slack.InteractionCallback) (interface{}, error) {
+	switch interaction.Type {
+	case slack.InteractionTypeBlockActions:
+		for _, blockAction := range interaction.ActionCallback.BlockActions {
+			h.mu.RLock()
+			fn, ok := h.actions[blockAction.ActionID]
+			h.mu.RUnlock()
+			if !ok {
+				continue
+			}
+
+			channelID := interaction.Channel.ID
+			if channelID == "" {
+				channelID = interaction.Container.ChannelID
+			}
+
+			action := &SlackAction{
+				ActionID:    blockAction.ActionID,
+				BlockID:     blockAction.BlockID,
+				Value:       blockAction.Value,
+				UserID:      interaction.User.ID,
+				ChannelID:   channelID,
+				MessageTS:   interaction.Container.MessageTs,
+				TriggerID:   interaction.TriggerID,
+				ResponseURL: interaction.ResponseURL,
+				Action:      blockAction,
+				Interaction: interaction,
+				httpClient:  h.httpClient,
+			}
+			if err := fn(ctx, action); err != nil {
+				return nil, fmt.Errorf("action %s: %w", blockAction.ActionID, err)
+			}
+		}
+
+	case slack.InteractionTypeViewSubmission:
+		h.mu.RLock()
+		fn, ok := h.views[interaction.View.CallbackID]
+		h.mu.RUnlock()
+		if !ok {
+			return nil, nil
+		}
+
+		submission := &SlackViewSubmission{
+			CallbackID:      interaction.View.CallbackID,
+			UserID:          interaction.User.ID,
+			PrivateMetadata: interaction.View.PrivateMetadata,
+			Interaction:     interaction,
+		}
+		if interaction.View.State != nil {
+			submission.Values = interaction.View.State.Values
+		}
+
+		response, err := fn(ctx, submission)
+		if err != nil {
+			return nil, fmt.Errorf("view %s: %w", interaction.View.CallbackID, err)
+		}
+		if response != nil {
+			return response, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// verifySlackRequest reads the request body and checks its signature against the signing secret.
+// On failure it writes an error response and returns a non-nil error.
+func verifySlackRequest(w http.ResponseWriter, r *http.Request, signingSecret string) ([]byte, error) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, fmt.Errorf("unexpected method %s", r.Method)
+	}
+
+	verifier, err := slack.NewSecretsVerifier(r.Header, signingSecret)
+	if err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return nil, err
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSlackRequestBody))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return nil, err
+	}
+
+	_, _ = verifier.Write(body)
+	if err := verifier.Ensure(); err != nil {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// respondSlack posts msg to a Slack response URL
+func respondSlack(ctx context.Context, client *http.Client, responseURL string, msg *SlackResponseMessage) error {
+	if responseURL == "" {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  "response URL is required",
+		}
+	}
+
+	return sendJSON(ctx, client, "slack", http.MethodPost, responseURL, nil, msg, nil)
+}
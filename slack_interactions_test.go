@@ -0,0 +1,107 @@
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// newSignedSlackRequest builds a request signed like Slack does
+func newSignedSlackRequest(secret, body string) *http.Request {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/slack", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func TestNewSlackInteractionHandler(t *testing.T) {
+	if _, err := NewSlackInteractionHandler(SlackInteractionConfig{}); err == nil {
+		t.Error("Expected error when signing secret is missing")
+	}
+}
+
+func TestSlackInteractionBlockAction(t *testing.T) {
+	var response SlackResponseMessage
+	responseServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&response); err != nil {
+			t.Errorf("Failed to decode response: %v", err)
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer responseServer.Close()
+
+	handler, err := NewSlackInteractionHandler(SlackInteractionConfig{SigningSecret: "secret"})
+	if err != nil {
+		t.Fatalf("Failed to create handler: %v", err)
+	}
+
+	var received *SlackAction
+	handler.OnAction("acknowledge", func(ctx context.Context, action *SlackAction) error {
+		received = action
+		return action.Respond(ctx, &SlackResponseMessage{Text: "Acknowledged", ReplaceOriginal: true})
+	})
+
+	payload := `{"type":"block_actions","user":{"id":"U1"},"channel":{"id":"C1"},"container":{"message_ts":"1.0"},` +
+		`"response_url":"` + responseServer.URL + `","actions":[{"action_id":"acknowledge","block_id":"b1","value":"incident-42"},{"action_id":"unknown"}]}`
+	body := url.Values{"payload": {payload}}.Encode()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newSignedSlackRequest("secret", body))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if received == nil || received.Value != "incident-42" || received.UserID != "U1" || received.ChannelID != "C1" || received.MessageTS != "1.0" {
+		t.Errorf("Unexpected action %+v", received)
+	}
+	if response.Text != "Acknowledged" || !response.ReplaceOriginal {
+		t.Errorf("Unexpected response %+v", response)
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newSignedSlackRequest("wrong", body))
+	if recorder.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for a bad signature, got %d", recorder.Code)
+	}
+}
+
+func TestSlackInteractionViewSubmission(t *testing.T) {
+	handler, err := NewSlackInteractionHandler(SlackInteractionConfig{SigningSecret: "secret"})
+	if err != nil {
+		t.Fatalf("Failed to create handler: %v", err)
+	}
+
+	handler.OnViewSubmission("silence", func(ctx context.Context, submission *SlackViewSubmission) (*slack.ViewSubmissionResponse, error) {
+		if submission.Values["duration"]["minutes"].Value != "abc" {
+			t.Errorf("Unexpected values %v", submission.Values)
+		}
+		return slack.NewErrorsViewSubmissionResponse(map[string]string{"duration": "Enter a number"}), nil
+	})
+
+	payload := `{"type":"view_submission","user":{"id":"U1"},"view":{"callback_id":"silence","state":{"values":{"duration":{"minutes":{"type":"plain_text_input","value":"abc"}}}}}}`
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, newSignedSlackRequest("secret", url.Values{"payload": {payload}}.Encode()))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body.String())
+	}
+	if !strings.Contains(recorder.Body.String(), `"response_action":"errors"`) || !strings.Contains(recorder.Body.String(), "Enter a number") {
+		t.Errorf("Unexpected response body %s", recorder.Body.String())
+	}
+}
@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// SlackReceipt references a message posted by SlackNotifier.Post
+type SlackReceipt struct {
+	// Channel is the ID of the channel the message was posted to
+	Channel string `json:"channel"`
+
+	// Timestamp is the message ts, which also identifies its thread
+	Timestamp string `json:"ts"`
+}
+
+// AddReaction adds an emoji reaction (e.g., "eyes" or ":white_check_mark:") to a message.
+// Adding a reaction that is already present is not an error.
+func (s *SlackNotifier) AddReaction(ctx context.Context, receipt *SlackReceipt, emoji string) error {
+	if err := s.checkReceipt(receipt); err != nil {
+		return err
+	}
+
+	err := s.client.AddReactionContext(ctx, strings.Trim(emoji, ":"), slack.NewRefToMessage(receipt.Channel, receipt.Timestamp))
+	return s.itemError(err, "already_reacted", "failed to add reaction")
+}
+
+// RemoveReaction removes an emoji reaction from a message.
+// Removing a reaction that isn't present is not an error.
+func (s *SlackNotifier) RemoveReaction(ctx context.Context, receipt *SlackReceipt, emoji string) error {
+	if err := s.checkReceipt(receipt); err != nil {
+		return err
+	}
+
+	err := s.client.RemoveReactionContext(ctx, strings.Trim(emoji, ":"), slack.NewRefToMessage(receipt.Channel, receipt.Timestamp))
+	return s.itemError(err, "no_reaction", "failed to remove reaction")
+}
+
+// Pin pins a message to its channel. Pinning a message that is already pinned is not an error.
+func (s *SlackNotifier) Pin(ctx context.Context, receipt *SlackReceipt) error {
+	if err := s.checkReceipt(receipt); err != nil {
+		return err
+	}
+
+	err := s.client.AddPinContext(ctx, receipt.Channel, slack.NewRefToMessage(receipt.Channel, receipt.Timestamp))
+	return s.itemError(err, "already_pinned", "failed to pin message")
+}
+
+// Unpin unpins a message from its channel. Unpinning a message that isn't pinned is not an error.
+func (s *SlackNotifier) Unpin(ctx context.Context, receipt *SlackReceipt) error {
+	if err := s.checkReceipt(receipt); err != nil {
+		return err
+	}
+
+	err := s.client.RemovePinContext(ctx, receipt.Channel, slack.NewRefToMessage(receipt.Channel, receipt.Timestamp))
+	return s.itemError(err, "no_pin", "failed to unpin message")
+}
+
+// checkReceipt validates the client and message reference
+func (s *SlackNotifier) checkReceipt(receipt *SlackReceipt) error {
+	if s.client == nil {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  "slack client not initialized",
+		}
+	}
+
+	if receipt == nil || receipt.Channel == "" || receipt.Timestamp == "" {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  "receipt with channel and timestamp is required",
+		}
+	}
+
+	return nil
+}
+
+// itemError wraps err, ignoring the Slack error code that means the change was already made
+func (s *SlackNotifier) itemError(err error, alreadyDone, message string) error {
+	if err == nil {
+		return nil
+	}
+
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) && slackErr.Err == alreadyDone {
+		return nil
+	}
+
+	return &NotificationError{
+		Provider: "slack",
+		Message:  message,
+		Err:      err,
+	}
+}
@@ -0,0 +1,333 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/slack-go/slack"
+)
+
+const (
+	// slackSocketReadTimeout drops connections that stop receiving pings or messages
+	slackSocketReadTimeout = 2 * time.Minute
+
+	// slackSocketWriteTimeout bounds writing an acknowledgement
+	slackSocketWriteTimeout = 10 * time.Second
+
+	// maxSlackSocketReconnectDelay caps the reconnect backoff
+	maxSlackSocketReconnectDelay = time.Minute
+)
+
+// SlackSocketModeConfig holds configuration for SlackSocketMode
+type SlackSocketModeConfig struct {
+	// AppToken is the app-level token (xapp-...) with the connections:write scope
+	AppToken string
+
+	// Interactions receives block actions and view submissions (optional)
+	Interactions *SlackInteractionHandler
+
+	// Commands receives slash commands (optional)
+	Commands *SlackCommandHandler
+
+	// APIURL overrides the Slack Web API base URL (optional, for testing; must end with a slash)
+	APIURL string
+
+	// HTTPClient is used to open connections (optional)
+	HTTPClient *http.Client
+
+	// ReconnectDelay is the initial delay before reconnecting, doubled on each failure (optional, default: 1s)
+	ReconnectDelay time.Duration
+
+	// OnError is called with callback errors, undecodable payloads and recovered callback panics (optional)
+	OnError func(error)
+}
+
+// SlackSocketMode receives Slack interactions and slash commands over a Socket Mode
+// websocket, so no public HTTP endpoint is needed. Payloads are dispatched to the
+// callbacks registered on the configured SlackInteractionHandler and SlackCommandHandler.
+type SlackSocketMode struct {
+	appToken       string
+	apiURL         string
+	httpClient     *http.Client
+	interactions   *SlackInteractionHandler
+	commands       *SlackCommandHandler
+	reconnectDelay time.Duration
+	onError        func(error)
+}
+
+// slackSocketEnvelope is a message received over the Socket Mode connection
+type slackSocketEnvelope struct {
+	Type       string          `json:"type"`
+	EnvelopeID string          `json:"envelope_id"`
+	Payload    json.RawMessage `json:"payload"`
+	Reason     string          `json:"reason"`
+}
+
+// slackSocketAck acknowledges an envelope, optionally with a response payload
+type slackSocketAck struct {
+	EnvelopeID string      `json:"envelope_id"`
+	Payload    interface{} `json:"payload,omitempty"`
+}
+
+// slackSocketConn serializes writes to a Socket Mode connection
+type slackSocketConn struct {
+	mu   sync.Mutex
+	conn *websocket.Conn
+}
+
+// ack writes an acknowledgement; failures are ignored since Slack retries unacknowledged envelopes
+func (c *slackSocketConn) ack(ack slackSocketAck) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(slackSocketWriteTimeout))
+	_ = c.conn.WriteJSON(ack)
+}
+
+// NewSlackSocketMode creates a new Socket Mode client
+func NewSlackSocketMode(config SlackSocketModeConfig) (*SlackSocketMode, error) {
+	if config.AppToken == "" {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "app token is required",
+		}
+	}
+
+	if config.Interactions == nil && config.Commands == nil {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "an interaction or command handler is required",
+		}
+	}
+
+	apiURL := config.APIURL
+	if apiURL == "" {
+		apiURL = slack.APIURL
+	}
+
+	reconnectDelay := config.ReconnectDelay
+	if reconnectDelay == 0 {
+		reconnectDelay = time.Second
+	}
+
+	return &SlackSocketMode{
+		appToken:       config.AppToken,
+		apiURL:         apiURL,
+		httpClient:     newHTTPClient(config.HTTPClient),
+		interactions:   config.Interactions,
+		commands:       config.Commands,
+		reconnectDelay: reconnectDelay,
+		onError:        config.OnError,
+	}, nil
+}
+
+// Run connects to Slack and handles payloads until ctx is cancelled.
+// Dropped connections and refresh requests are reconnected with exponential backoff.
+// It returns ctx.Err() when cancelled, or an error when Slack rejects the app token
+// or disables the connection.
+func (s *SlackSocketMode) Run(ctx context.Context) error {
+	delay := s.reconnectDelay
+
+	for {
+		connected, retry, err := s.session(ctx)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !retry {
+			return err
+		}
+
+		if connected {
+			delay = s.reconnectDelay
+		}
+
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(delay):
+			}
+
+			delay *= 2
+			if delay > maxSlackSocketReconnectDelay {
+				delay = maxSlackSocketReconnectDelay
+			}
+		}
+	}
+}
+
+// session runs a single connection. It reports whether Slack said hello, whether
+// Run should reconnect, and the error that ended the session (nil for a refresh).
+func (s *SlackSocketMode) session(ctx context.Context) (connected, retry bool, err error) {
+	endpoint, retry, err := s.openConnection(ctx)
+	if err != nil {
+		return false, retry, err
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, endpoint, nil)
+	if err != nil {
+		return false, true, &NotificationError{
+			Provider: "slack",
+			Message:  "failed to connect to socket mode",
+			Err:      err,
+		}
+	}
+	defer conn.Close()
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = conn.Close()
+		case <-done:
+		}
+	}()
+
+	socket := &slackSocketConn{conn: conn}
+	_ = conn.SetReadDeadline(time.Now().Add(slackSocketReadTimeout))
+	conn.SetPingHandler(func(data string) error {
+		_ = conn.SetReadDeadline(time.Now().Add(slackSocketReadTimeout))
+		return conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(slackSocketWriteTimeout))
+	})
+
+	for {
+		var envelope slackSocketEnvelope
+		if err := conn.ReadJSON(&envelope); err != nil {
+			return connected, true, &NotificationError{
+				Provider: "slack",
+				Message:  "socket mode connection lost",
+				Err:      err,
+			}
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(slackSocketReadTimeout))
+
+		switch envelope.Type {
+		case "hello":
+			connected = true
+		case "disconnect":
+			if envelope.Reason == "link_disabled" {
+				return connected, false, &NotificationError{
+					Provider: "slack",
+					Message:  "socket mode was disabled for this app",
+				}
+			}
+			return connected, true, nil
+		default:
+			if envelope.EnvelopeID != "" {
+				go s.handle(ctx, socket, envelope)
+			}
+		}
+	}
+}
+
+// handle dispatches an envelope and acknowledges it with the callback's response.
+// Envelopes are acknowledged even when a callback fails or panics, so Slack doesn't retry them.
+func (s *SlackSocketMode) handle(ctx context.Context, socket *slackSocketConn, envelope slackSocketEnvelope) {
+	ack := slackSocketAck{EnvelopeID: envelope.EnvelopeID}
+	defer func() {
+		if r := recover(); r != nil {
+			ack.Payload = nil
+			s.reportError(&NotificationError{
+				Provider: "slack",
+				Message:  fmt.Sprintf("panic handling %s envelope", envelope.Type),
+				Err:      fmt.Errorf("%v", r),
+			})
+		}
+		socket.ack(ack)
+	}()
+
+	switch envelope.Type {
+	case "interactive":
+		if s.interactions == nil {
+			return
+		}
+
+		var interaction slack.InteractionCallback
+		if err := json.Unmarshal(envelope.Payload, &interaction); err != nil {
+			s.reportError(&NotificationError{
+				Provider: "slack",
+				Message:  "invalid interactive payload",
+				Err:      err,
+			})
+			return
+		}
+
+		response, err := s.interactions.dispatch(ctx, &interaction)
+		if err != nil {
+			s.reportError(&NotificationError{
+				Provider: "slack",
+				Message:  "interaction callback failed",
+				Err:      err,
+			})
+			return
+		}
+		if response != nil {
+			ack.Payload = response
+		}
+
+	case "slash_commands":
+		if s.commands == nil {
+			return
+		}
+
+		var fields map[string]interface{}
+		if err := json.Unmarshal(envelope.Payload, &fields); err != nil {
+			s.reportError(&NotificationError{
+				Provider: "slack",
+				Message:  "invalid slash command payload",
+				Err:      err,
+			})
+			return
+		}
+
+		form := url.Values{}
+		for key, value := range fields {
+			if str, ok := value.(string); ok {
+				form.Set(key, str)
+			}
+		}
+
+		if response := s.commands.dispatch(ctx, parseSlackCommand(form)); response != nil {
+			ack.Payload = response
+		}
+	}
+}
+
+// reportError passes err to the OnError callback, if any
+func (s *SlackSocketMode) reportError(err error) {
+	if s.onError != nil {
+		s.onError(err)
+	}
+}
+
+// openConnection requests a websocket URL from apps.connections.open.
+// API errors, such as an invalid token, are not worth retrying.
+func (s *SlackSocketMode) openConnection(ctx context.Context) (endpoint string, retry bool, err error) {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+s.appToken)
+
+	var result struct {
+		slack.SlackResponse
+		URL string `json:"url"`
+	}
+	if err := sendForm(ctx, s.httpClient, "slack", s.apiURL+"apps.connections.open", header, url.Values{}, &result); err != nil {
+		return "", true, err
+	}
+
+	if err := result.Err(); err != nil {
+		return "", false, &NotificationError{
+			Provider: "slack",
+			Message:  "apps.connections.open failed",
+			Err:      err,
+		}
+	}
+
+	return result.URL, false, nil
+}
@@ -0,0 +1,231 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/slack-go/slack"
+)
+
+func TestNewSlackSocketMode(t *testing.T) {
+	if _, err := NewSlackSocketMode(SlackSocketModeConfig{AppToken: "xapp-test"}); err == nil {
+		t.Error("Expected error when no handler is configured")
+	}
+}
+
+func TestSlackSocketMode(t *testing.T) {
+	interactions, _ := NewSlackInteractionHandler(SlackInteractionConfig{SigningSecret: "secret"})
+	commands, _ := NewSlackCommandHandler(SlackCommandConfig{SigningSecret: "secret"})
+
+	actions := make(chan string, 1)
+	interactions.OnAction("acknowledge", func(ctx context.Context, action *SlackAction) error {
+		actions <- action.Value
+		return nil
+	})
+	interactions.OnViewSubmission("silence", func(ctx context.Context, submission *SlackViewSubmission) (*slack.ViewSubmissionResponse, error) {
+		return slack.NewClearViewSubmissionResponse(), nil
+	})
+	commands.Handle("status", func(ctx context.Context, cmd *SlackCommand) (*SlackResponseMessage, error) {
+		return &SlackResponseMessage{Text: "ok for " + cmd.UserID}, nil
+	})
+
+	acks := make(chan map[string]interface{}, 4)
+	var connections int32
+	upgrader := websocket.Upgrader{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apps.connections.open":
+			if r.Header.Get("Authorization") != "Bearer xapp-test" {
+				t.Errorf("Unexpected Authorization header: %s", r.Header.Get("Authorization"))
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"url":"ws` + strings.TrimPrefix(server.URL, "http") + `/ws"}`))
+		case "/ws":
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				t.Errorf("Upgrade failed: %v", err)
+				return
+			}
+			defer conn.Close()
+
+			_ = conn.WriteJSON(map[string]string{"type": "hello"})
+
+			if atomic.AddInt32(&connections, 1) > 1 {
+				// Keep the second connection open until the client goes away
+				_, _, _ = conn.ReadMessage()
+				return
+			}
+
+			envelopes := []string{
+				`{"type":"interactive","envelope_id":"e1","payload":{"type":"block_actions","user":{"id":"U1"},"actions":[{"action_id":"acknowledge","block_id":"b1","value":"incident-42"}]}}`,
+				`{"type":"interactive","envelope_id":"e2","payload":{"type":"view_submission","view":{"callback_id":"silence"}}}`,
+				`{"type":"slash_commands","envelope_id":"e3","payload":{"command":"/notify","text":"status","user_id":"U1","is_enterprise_install":false}}`,
+			}
+			for _, envelope := range envelopes {
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(envelope))
+
+				var ack map[string]interface{}
+				if err := conn.ReadJSON(&ack); err != nil {
+					t.Errorf("Failed to read ack: %v", err)
+					return
+				}
+				acks <- ack
+			}
+
+			_ = conn.WriteJSON(map[string]string{"type": "disconnect", "reason": "refresh_requested"})
+			_, _, _ = conn.ReadMessage()
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewSlackSocketMode(SlackSocketModeConfig{
+		AppToken:       "xapp-test",
+		Interactions:   interactions,
+		Commands:       commands,
+		APIURL:         server.URL + "/",
+		ReconnectDelay: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error, 1)
+	go func() {
+		result <- client.Run(ctx)
+	}()
+
+	if value := <-actions; value != "incident-42" {
+		t.Errorf("Unexpected action value %s", value)
+	}
+
+	if ack := <-acks; ack["envelope_id"] != "e1" || ack["payload"] != nil {
+		t.Errorf("Unexpected action ack %v", ack)
+	}
+	if ack := <-acks; ack["envelope_id"] != "e2" || ack["payload"].(map[string]interface{})["response_action"] != "clear" {
+		t.Errorf("Unexpected view ack %v", ack)
+	}
+	if ack := <-acks; ack["envelope_id"] != "e3" || ack["payload"].(map[string]interface{})["text"] != "ok for U1" {
+		t.Errorf("Unexpected command ack %v", ack)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for atomic.LoadInt32(&connections) < 2 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if atomic.LoadInt32(&connections) != 2 {
+		t.Errorf("Expected a reconnect after the refresh request, got %d connections", connections)
+	}
+
+	cancel()
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestSlackSocketModeInvalidToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+	}))
+	defer server.Close()
+
+	commands, _ := NewSlackCommandHandler(SlackCommandConfig{SigningSecret: "secret"})
+	client, err := NewSlackSocketMode(SlackSocketModeConfig{AppToken: "xapp-bad", Commands: commands, APIURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	if err := client.Run(context.Background()); err == nil || !strings.Contains(err.Error(), "invalid_auth") {
+		t.Errorf("Expected invalid_auth error, got %v", err)
+	}
+}
+
+func TestSlackSocketModeCallbackFailures(t *testing.T) {
+	interactions, _ := NewSlackInteractionHandler(SlackInteractionConfig{SigningSecret: "secret"})
+	commands, _ := NewSlackCommandHandler(SlackCommandConfig{SigningSecret: "secret"})
+
+	interactions.OnAction("fail", func(ctx context.Context, action *SlackAction) error {
+		return errors.New("ticket system unavailable")
+	})
+	commands.Handle("crash", func(ctx context.Context, cmd *SlackCommand) (*SlackResponseMessage, error) {
+		panic("nil map")
+	})
+
+	acks := make(chan map[string]interface{}, 3)
+	upgrader := websocket.Upgrader{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/apps.connections.open":
+			_, _ = w.Write([]byte(`{"ok":true,"url":"ws` + strings.TrimPrefix(server.URL, "http") + `/ws"}`))
+		case "/ws":
+			conn, err := upgrader.Upgrade(w, r, nil)
+			if err != nil {
+				t.Errorf("Upgrade failed: %v", err)
+				return
+			}
+			defer conn.Close()
+
+			envelopes := []string{
+				`{"type":"interactive","envelope_id":"e1","payload":{"type":"block_actions","actions":[{"action_id":"fail","block_id":"b1"}]}}`,
+				`{"type":"slash_commands","envelope_id":"e2","payload":{"command":"/notify","text":"crash"}}`,
+				`{"type":"interactive","envelope_id":"e3","payload":"not an interaction"}`,
+			}
+			for _, envelope := range envelopes {
+				_ = conn.WriteMessage(websocket.TextMessage, []byte(envelope))
+
+				var ack map[string]interface{}
+				if err := conn.ReadJSON(&ack); err != nil {
+					t.Errorf("Failed to read ack: %v", err)
+					return
+				}
+				acks <- ack
+			}
+			_, _, _ = conn.ReadMessage()
+		}
+	}))
+	defer server.Close()
+
+	errs := make(chan error, 3)
+	client, err := NewSlackSocketMode(SlackSocketModeConfig{
+		AppToken:     "xapp-test",
+		Interactions: interactions,
+		Commands:     commands,
+		APIURL:       server.URL + "/",
+		OnError:      func(err error) { errs <- err },
+	})
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = client.Run(ctx)
+	}()
+
+	for _, want := range []struct {
+		envelopeID string
+		err        string
+	}{
+		{"e1", "ticket system unavailable"},
+		{"e2", "panic handling slash_commands envelope"},
+		{"e3", "invalid interactive payload"},
+	} {
+		if ack := <-acks; ack["envelope_id"] != want.envelopeID || ack["payload"] != nil {
+			t.Errorf("Unexpected ack %v", ack)
+		}
+		if err := <-errs; !strings.Contains(err.Error(), want.err) {
+			t.Errorf("Expected error containing %q, got %v", want.err, err)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/slack-go/slack"
+)
+
+// slackCodeFence opens and closes preformatted text in mrkdwn
+const slackCodeFence = "```"
+
+// sendLongMessage posts text that doesn't fit in a single section block.
+// The text is split into sections; sections that don't fit in the message, or every
+// section after the first when ThreadLongMessages is set, are posted as thread replies.
+func (s *SlackNotifier) sendLongMessage(ctx context.Context, channel string, msg *Message, chunks []string) (*SlackReceipt, error) {
+	var blocks []slack.Block
+	if msg.Title != "" {
+		blocks = append(blocks, slack.NewHeaderBlock(
+			slack.NewTextBlockObject(slack.PlainTextType, msg.Title, false, false),
+		))
+	}
+
+	count := slackMaxBlocks - len(blocks)
+	if s.threadLongMessages {
+		count = 1
+	}
+	if count > len(chunks) {
+		count = len(chunks)
+	}
+	blocks = append(blocks, slackSections(chunks[:count])...)
+
+	options := append(s.identityOptions(),
+		slack.MsgOptionText(slackFallbackText(msg.Title, chunks[0]), false),
+		slack.MsgOptionBlocks(blocks...),
+	)
+	if len(msg.Attachments) > 0 {
+		options = append(options, slack.MsgOptionAttachments(s.convertAttachments(msg.Attachments)...))
+	}
+
+	channelID, ts, err := s.client.PostMessageContext(ctx, channel, options...)
+	if err != nil {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "failed to send message",
+			Err:      err,
+		}
+	}
+	receipt := &SlackReceipt{Channel: channelID, Timestamp: ts}
+
+	perReply := slackMaxBlocks
+	if s.threadLongMessages {
+		perReply = 1
+	}
+
+	for rest := chunks[count:]; len(rest) > 0; {
+		n := perReply
+		if n > len(rest) {
+			n = len(rest)
+		}
+
+		replyOptions := append(s.identityOptions(),
+			slack.MsgOptionTS(ts),
+			slack.MsgOptionText(rest[0], false),
+			slack.MsgOptionBlocks(slackSections(rest[:n])...),
+		)
+		if _, _, err := s.client.PostMessageContext(ctx, channel, replyOptions...); err != nil {
+			return receipt, &NotificationError{
+				Provider: "slack",
+				Message:  "failed to send thread reply",
+				Err:      err,
+			}
+		}
+		rest = rest[n:]
+	}
+
+	return receipt, nil
+}
+
+// sendSnippet posts a preview of the text and uploads the full text as a snippet in its thread
+func (s *SlackNotifier) sendSnippet(ctx context.Context, channel string, msg *Message, chunks []string) (*SlackReceipt, error) {
+	length := utf8.RuneCountInString(msg.Text)
+
+	var blocks []slack.Block
+	if msg.Title != "" {
+		blocks = append(blocks, slack.NewHeaderBlock(
+			slack.NewTextBlockObject(slack.PlainTextType, msg.Title, false, false),
+		))
+	}
+	blocks = append(blocks, slackSections(chunks[:1])...)
+	blocks = append(blocks, slack.NewContextBlock("", slack.NewTextBlockObject(
+		slack.MarkdownType, fmt.Sprintf("Full message (%d characters) attached in thread", length), false, false,
+	)))
+
+	options := append(s.identityOptions(),
+		slack.MsgOptionText(slackFallbackText(msg.Title, chunks[0]), false),
+		slack.MsgOptionBlocks(blocks...),
+	)
+	if len(msg.Attachments) > 0 {
+		options = append(options, slack.MsgOptionAttachments(s.convertAttachments(msg.Attachments)...))
+	}
+
+	channelID, ts, err := s.client.PostMessageContext(ctx, channel, options...)
+	if err != nil {
+		return nil, &NotificationError{
+			Provider: "slack",
+			Message:  "failed to send message",
+			Err:      err,
+		}
+	}
+	receipt := &SlackReceipt{Channel: channelID, Timestamp: ts}
+
+	title := msg.Title
+	if title == "" {
+		title = "Full message"
+	}
+
+	_, err = s.UploadFiles(ctx, &SlackUpload{
+		Channel:  channelID,
+		ThreadTS: ts,
+		Files: []SlackFile{
+			{Filename: "message.txt", Title: title, ContentType: "text/plain", SnippetType: "text", Content: []byte(msg.Text)},
+		},
+	})
+	return receipt, err
+}
+
+// slackFallbackText is the notification text of a split message: the title, or the first part
+func slackFallbackText(title, chunk string) string {
+	if title != "" {
+		return title
+	}
+	return chunk
+}
+
+// slackSections wraps each chunk of mrkdwn text in a section block
+func slackSections(chunks []string) []slack.Block {
+	blocks := make([]slack.Block, len(chunks))
+	for i, chunk := range chunks {
+		blocks[i] = slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, chunk, false, false), nil, nil)
+	}
+	return blocks
+}
+
+// splitSlackText splits text into chunks of at most limit characters, breaking on line
+// boundaries where possible. A chunk that ends inside a code fence is closed and the
+// fence is reopened at the start of the next chunk.
+func splitSlackText(text string, limit int) []string {
+	// Reserve room for closing a code fence at the end of a chunk
+	budget := limit - len("\n"+slackCodeFence)
+	// Longest piece that fits after a reopened code fence
+	maxPiece := budget - len(slackCodeFence+"\n")
+
+	var chunks []string
+	var lines []string
+	size := 0
+	inFence := false
+
+	add := func(line string) {
+		if len(lines) > 0 {
+			size++
+		}
+		lines = append(lines, line)
+		size += utf8.RuneCountInString(line)
+	}
+
+	flush := func() {
+		chunk := strings.Join(lines, "\n")
+		if inFence {
+			chunk += "\n" + slackCodeFence
+		}
+		chunks = append(chunks, chunk)
+		lines, size = nil, 0
+		if inFence {
+			add(slackCodeFence)
+		}
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		// A line such as "```code```" opens and closes a fence, so only an odd count toggles it
+		toggles := strings.Count(line, slackCodeFence)%2 == 1
+
+		if toggles && inFence && strings.TrimSpace(line) == slackCodeFence && len(lines) > 0 && size+1+utf8.RuneCountInString(line) > budget {
+			// The chunk is full at the closing fence: flush closes the fence for us
+			flush()
+			inFence = false
+			lines, size = nil, 0
+			continue
+		}
+
+		for _, piece := range splitRunes(line, maxPiece) {
+			extra := utf8.RuneCountInString(piece)
+			if len(lines) > 0 {
+				extra++
+			}
+			if len(lines) > 0 && size+extra > budget {
+				flush()
+			}
+			add(piece)
+		}
+
+		if toggles {
+			inFence = !inFence
+		}
+	}
+
+	if len(lines) > 0 {
+		flush()
+	}
+
+	return chunks
+}
+
+// splitRunes splits s into pieces of at most n runes
+func splitRunes(s string, n int) []string {
+	if utf8.RuneCountInString(s) <= n {
+		return []string{s}
+	}
+
+	var pieces []string
+	for len(s) > 0 {
+		end, count := 0, 0
+		for end < len(s) && count < n {
+			_, width := utf8.DecodeRuneInString(s[end:])
+			end += width
+			count++
+		}
+		pieces = append(pieces, s[:end])
+		s = s[end:]
+	}
+	return pieces
+}
@@ -0,0 +1,185 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestSplitSlackText(t *testing.T) {
+	if chunks := splitSlackText("short\ntext", 100); len(chunks) != 1 || chunks[0] != "short\ntext" {
+		t.Errorf("Expected text to stay in one chunk, got %q", chunks)
+	}
+
+	lines := make([]string, 30)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %02d", i)
+	}
+	text := strings.Join(lines, "\n")
+	chunks := splitSlackText(text, 40)
+	for _, chunk := range chunks {
+		if utf8.RuneCountInString(chunk) > 40 {
+			t.Errorf("Chunk exceeds limit: %q", chunk)
+		}
+		if strings.HasPrefix(chunk, "\n") || strings.HasSuffix(chunk, "\n") {
+			t.Errorf("Expected split on line boundary, got %q", chunk)
+		}
+	}
+	if strings.Join(chunks, "\n") != text {
+		t.Error("Expected chunks to reassemble into the original text")
+	}
+
+	fenced := "Stack trace:\n```\n" + strings.Join(lines, "\n") + "\n```\ndone"
+	chunks = splitSlackText(fenced, 40)
+	for i, chunk := range chunks {
+		if utf8.RuneCountInString(chunk) > 40 {
+			t.Errorf("Chunk exceeds limit: %q", chunk)
+		}
+		if strings.Count(chunk, "```")%2 != 0 {
+			t.Errorf("Chunk %d has an unbalanced code fence: %q", i, chunk)
+		}
+	}
+	if !strings.HasPrefix(chunks[1], "```\n") {
+		t.Errorf("Expected code fence to be reopened, got %q", chunks[1])
+	}
+	if last := chunks[len(chunks)-1]; !strings.HasSuffix(last, "done") {
+		t.Errorf("Expected text after the fence in the last chunk, got %q", last)
+	}
+
+	inline := "```make test``` runs the suite\n" + strings.Join(lines, "\n")
+	for i, chunk := range splitSlackText(inline, 40) {
+		if strings.Count(chunk, "```")%2 != 0 || (i > 0 && strings.Contains(chunk, "```")) {
+			t.Errorf("Expected inline code not to open a fence, got chunk %d %q", i, chunk)
+		}
+	}
+
+	chunks = splitSlackText(strings.Repeat("é", 100), 40)
+	if len(chunks) < 3 || strings.Join(chunks, "") != strings.Repeat("é", 100) {
+		t.Errorf("Expected long line to be split on rune boundaries, got %q", chunks)
+	}
+	for _, chunk := range chunks {
+		if !utf8.ValidString(chunk) || utf8.RuneCountInString(chunk) > 40 {
+			t.Errorf("Unexpected chunk %q", chunk)
+		}
+	}
+}
+
+// newSlackMessageServer records chat.postMessage calls and accepts external uploads
+func newSlackMessageServer(t *testing.T) (*httptest.Server, *[]map[string]string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var posts []map[string]string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+
+		switch {
+		case r.URL.Path == "/chat.postMessage":
+			mu.Lock()
+			posts = append(posts, map[string]string{"blocks": r.FormValue("blocks"), "text": r.FormValue("text"), "thread_ts": r.FormValue("thread_ts")})
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"ok":true,"channel":"C1","ts":"1.0"}`))
+		case r.URL.Path == "/files.getUploadURLExternal":
+			_, _ = w.Write([]byte(`{"ok":true,"upload_url":"` + server.URL + `/upload","file_id":"F1"}`))
+		case r.URL.Path == "/upload":
+			_, _ = w.Write([]byte("OK"))
+		case r.URL.Path == "/files.completeUploadExternal":
+			mu.Lock()
+			posts = append(posts, map[string]string{"files": r.FormValue("files"), "thread_ts": r.FormValue("thread_ts"), "channel": r.FormValue("channel_id")})
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"ok":true,"files":[{"id":"F1"}]}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+
+	return server, &posts
+}
+
+func TestSlackSendLongMessage(t *testing.T) {
+	text := strings.Repeat(strings.Repeat("x", 99)+"\n", 70)
+
+	// 7000 characters split into three sections
+	for _, tt := range []struct {
+		name    string
+		thread  bool
+		blocks  int
+		replies int
+	}{
+		{name: "blocks", thread: false, blocks: 4, replies: 0},
+		{name: "thread", thread: true, blocks: 2, replies: 2},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			server, posts := newSlackMessageServer(t)
+			defer server.Close()
+
+			notifier, err := NewSlackNotifier(&SlackConfig{Token: "xoxb-test", DefaultChannel: "C1", APIURL: server.URL + "/", ThreadLongMessages: tt.thread})
+			if err != nil {
+				t.Fatalf("Failed to create notifier: %v", err)
+			}
+
+			if err := notifier.SendWithOptions(context.Background(), &Message{Title: "Logs", Text: text}); err != nil {
+				t.Fatalf("SendWithOptions failed: %v", err)
+			}
+
+			if len(*posts) != 1+tt.replies {
+				t.Fatalf("Expected %d posts, got %d", 1+tt.replies, len(*posts))
+			}
+
+			var blocks []map[string]interface{}
+			if err := json.Unmarshal([]byte((*posts)[0]["blocks"]), &blocks); err != nil {
+				t.Fatalf("Failed to decode blocks: %v", err)
+			}
+			if len(blocks) != tt.blocks || blocks[0]["type"] != "header" {
+				t.Errorf("Unexpected blocks %v", blocks)
+			}
+			if (*posts)[0]["text"] != "Logs" {
+				t.Errorf("Expected title as fallback text, got %q", (*posts)[0]["text"])
+			}
+			for _, reply := range (*posts)[1:] {
+				if reply["thread_ts"] != "1.0" {
+					t.Errorf("Expected thread reply, got %v", reply)
+				}
+				if !strings.HasPrefix(reply["text"], "xxx") {
+					t.Errorf("Expected reply fallback text, got %q", reply["text"])
+				}
+			}
+		})
+	}
+}
+
+func TestSlackSendSnippet(t *testing.T) {
+	server, posts := newSlackMessageServer(t)
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(&SlackConfig{Token: "xoxb-test", DefaultChannel: "C1", APIURL: server.URL + "/", SnippetThreshold: 5000})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if err := notifier.SendWithOptions(context.Background(), &Message{Text: strings.Repeat("y", 6000)}); err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if len(*posts) != 2 {
+		t.Fatalf("Expected a preview and an upload, got %v", *posts)
+	}
+	if !strings.Contains((*posts)[0]["blocks"], "Full message (6000 characters) attached in thread") {
+		t.Errorf("Unexpected preview %s", (*posts)[0]["blocks"])
+	}
+	if text := (*posts)[0]["text"]; !strings.HasPrefix(text, "yyy") || utf8.RuneCountInString(text) > slackMaxTextLength {
+		t.Errorf("Expected first part as fallback text, got %d characters", utf8.RuneCountInString(text))
+	}
+	if (*posts)[1]["thread_ts"] != "1.0" || (*posts)[1]["channel"] != "C1" || !strings.Contains((*posts)[1]["files"], "Full message") {
+		t.Errorf("Unexpected upload %v", (*posts)[1])
+	}
+}
@@ -0,0 +1,453 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newSlackTestServer starts a fake Slack Web API that accepts external uploads
+func newSlackTestServer(t *testing.T, complete func(r *http.Request)) (*httptest.Server, map[string]string) {
+	t.Helper()
+
+	var mu sync.Mutex
+	uploads := map[string]string{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/files.getUploadURLExternal":
+			if r.Header.Get("Authorization") != "Bearer xoxb-test" {
+				t.Errorf("Unexpected Authorization header: %s", r.Header.Get("Authorization"))
+			}
+			id := "F" + r.FormValue("filename")
+			_, _ = w.Write([]byte(`{"ok":true,"upload_url":"` + server.URL + `/upload/` + id + `","file_id":"` + id + `"}`))
+		case strings.HasPrefix(r.URL.Path, "/upload/"):
+			data, _ := io.ReadAll(r.Body)
+			mu.Lock()
+			uploads[strings.TrimPrefix(r.URL.Path, "/upload/")] = r.Header.Get("Content-Type") + ":" + string(data)
+			mu.Unlock()
+			_, _ = w.Write([]byte("OK"))
+		case r.URL.Path == "/api/files.completeUploadExternal":
+			if err := r.ParseForm(); err != nil {
+				t.Errorf("Failed to parse form: %v", err)
+			}
+			complete(r)
+			_, _ = w.Write([]byte(`{"ok":true,"files":` + r.FormValue("files") + `}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+
+	return server, uploads
+}
+
+func TestSlackUploadFiles(t *testing.T) {
+	var form map[string][]string
+	server, uploads := newSlackTestServer(t, func(r *http.Request) {
+		form = r.PostForm
+	})
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(&SlackConfig{
+		Token:          "xoxb-test",
+		DefaultChannel: "C123",
+		APIURL:         server.URL + "/api/",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	ids, err := notifier.UploadFiles(context.Background(), &SlackUpload{
+		ThreadTS: "1700000000.000100",
+		Comment:  "Build artifacts",
+		Files: []SlackFile{
+			{Filename: "report.json", Title: "Report", Content: []byte(`{"ok":true}`)},
+			{Filename: "build.log", ContentType: "text/plain", Reader: strings.NewReader("done")},
+		},
+	})
+	if err != nil {
+		t.Fatalf("UploadFiles failed: %v", err)
+	}
+
+	if len(ids) != 2 || ids[0] != "Freport.json" || ids[1] != "Fbuild.log" {
+		t.Errorf("Unexpected file IDs %v", ids)
+	}
+	if uploads["Freport.json"] != `application/json:{"ok":true}` || uploads["Fbuild.log"] != "text/plain:done" {
+		t.Errorf("Unexpected uploads %v", uploads)
+	}
+
+	if form["channel_id"][0] != "C123" || form["thread_ts"][0] != "1700000000.000100" || form["initial_comment"][0] != "Build artifacts" {
+		t.Errorf("Unexpected complete form %v", form)
+	}
+
+	var files []map[string]string
+	if err := json.Unmarshal([]byte(form["files"][0]), &files); err != nil {
+		t.Fatalf("Failed to decode files: %v", err)
+	}
+	if files[0]["title"] != "Report" || files[1]["title"] != "build.log" {
+		t.Errorf("Unexpected file titles %v", files)
+	}
+}
+
+func TestSlackSendFile(t *testing.T) {
+	var channel string
+	server, uploads := newSlackTestServer(t, func(r *http.Request) {
+		channel = r.FormValue("channel_id")
+	})
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(&SlackConfig{Token: "xoxb-test", DefaultChannel: "C123", APIURL: server.URL + "/api/"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("hello"), 0o600); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+
+	if err := notifier.SendFile(context.Background(), "C999", path, "Notes", ""); err != nil {
+		t.Fatalf("SendFile failed: %v", err)
+	}
+
+	if channel != "C999" {
+		t.Errorf("Expected channel C999, got %s", channel)
+	}
+	if !strings.HasSuffix(uploads["Fnotes.txt"], ":hello") {
+		t.Errorf("Unexpected uploads %v", uploads)
+	}
+}
+
+func TestSlackUploadFilesError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ok":false,"error":"not_in_channel"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(&SlackConfig{Token: "xoxb-test", DefaultChannel: "C123", APIURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	_, err = notifier.UploadFiles(context.Background(), &SlackUpload{Files: []SlackFile{{Filename: "a.txt", Content: []byte("a")}}})
+	if err == nil || !strings.Contains(err.Error(), "not_in_channel") {
+		t.Errorf("Expected not_in_channel error, got %v", err)
+	}
+
+	_, err = notifier.UploadFiles(context.Background(), &SlackUpload{Files: []SlackFile{{Filename: "empty.txt"}}})
+	if err == nil {
+		t.Error("Expected error for empty file")
+	}
+}
+
+func TestSlackSendDirectByEmail(t *testing.T) {
+	calls := map[string]int{}
+	var posted map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+		calls[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/users.lookupByEmail":
+			if r.FormValue("email") != "jane@example.com" {
+				t.Errorf("Unexpected email %s", r.FormValue("email"))
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"user":{"id":"U1"}}`))
+		case "/conversations.open":
+			if r.FormValue("users") != "U1" {
+				t.Errorf("Unexpected users %s", r.FormValue("users"))
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"channel":{"id":"D1"}}`))
+		case "/chat.postMessage":
+			posted = r.PostForm
+			_, _ = w.Write([]byte(`{"ok":true,"channel":"D1","ts":"1.0"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(&SlackConfig{Token: "xoxb-test", APIURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := notifier.SendDirectByEmail(context.Background(), "Jane@example.com", &Message{Text: "Your report is ready"}); err != nil {
+			t.Fatalf("SendDirectByEmail failed: %v", err)
+		}
+	}
+
+	if calls["/users.lookupByEmail"] != 1 || calls["/conversations.open"] != 1 || calls["/chat.postMessage"] != 2 {
+		t.Errorf("Expected cached lookups, got %v", calls)
+	}
+	if posted["channel"][0] != "D1" || posted["text"][0] != "Your report is ready" {
+		t.Errorf("Unexpected message %v", posted)
+	}
+}
+
+func TestSlackSendEphemeral(t *testing.T) {
+	var form map[string][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/chat.postEphemeral" {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+		form = r.PostForm
+		_, _ = w.Write([]byte(`{"ok":true,"message_ts":"1.0"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(&SlackConfig{Token: "xoxb-test", DefaultChannel: "C123", APIURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if err := notifier.SendEphemeral(context.Background(), "", "U1", &Message{Text: "Only you can see this"}); err != nil {
+		t.Fatalf("SendEphemeral failed: %v", err)
+	}
+
+	if form["channel"][0] != "C123" || form["user"][0] != "U1" || form["text"][0] != "Only you can see this" {
+		t.Errorf("Unexpected form %v", form)
+	}
+
+	if err := notifier.SendEphemeral(context.Background(), "C123", "", &Message{Text: "hi"}); err == nil {
+		t.Error("Expected error when user ID is missing")
+	}
+}
+
+func TestSlackResolveChannel(t *testing.T) {
+	calls := map[string]int{}
+	var joined, posted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+		calls[r.URL.Path]++
+
+		switch r.URL.Path {
+		case "/conversations.list":
+			if r.FormValue("cursor") == "" {
+				_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C1","name":"general","is_member":true}],"response_metadata":{"next_cursor":"page2"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"ok":true,"channels":[{"id":"C2","name":"alerts","is_member":false},{"id":"G3","name":"secret","is_private":true}]}`))
+		case "/conversations.join":
+			joined = r.FormValue("channel")
+			_, _ = w.Write([]byte(`{"ok":true,"channel":{"id":"C2"}}`))
+		case "/chat.postMessage":
+			posted = r.FormValue("channel")
+			_, _ = w.Write([]byte(`{"ok":true,"channel":"C1","ts":"1.0"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(&SlackConfig{Token: "xoxb-test", DefaultChannel: "#general", APIURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := notifier.Send(ctx, "hello"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if posted != "C1" {
+		t.Errorf("Expected message posted to C1, got %s", posted)
+	}
+
+	for _, id := range []string{"C0123456789", "G0123456789", "D0123456789", "U0123456789", "W0123456789"} {
+		if resolved, err := notifier.ResolveChannel(ctx, id); err != nil || resolved != id {
+			t.Errorf("Expected ID %s to pass through, got %s, %v", id, resolved, err)
+		}
+	}
+
+	// Public channels the bot isn't in are posted to directly (chat:write.public)
+	if id, err := notifier.ResolveChannel(ctx, "#alerts"); err != nil || id != "C2" {
+		t.Errorf("Expected C2 for a public channel the bot isn't in, got %s, %v", id, err)
+	}
+	if calls["/conversations.list"] != 2 {
+		t.Errorf("Expected cached pages to be reused, got %d list calls", calls["/conversations.list"])
+	}
+
+	for i := 0; i < 3; i++ {
+		if id, err := notifier.ResolveChannel(ctx, "#missing"); err != nil || id != "#missing" {
+			t.Errorf("Expected unknown name to pass through, got %s, %v", id, err)
+		}
+	}
+	if calls["/conversations.list"] != 2 {
+		t.Errorf("Expected unknown names not to relist channels, got %d list calls", calls["/conversations.list"])
+	}
+
+	// Once the miss interval has passed, an unknown name refreshes the cache again
+	notifier.mu.Lock()
+	notifier.channelsTried = notifier.channelsTried.Add(-slackChannelMissRefreshInterval)
+	notifier.mu.Unlock()
+	notifier.strictChannels = true
+	if _, err := notifier.ResolveChannel(ctx, "#missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("Expected not found error in strict mode, got %v", err)
+	}
+	if calls["/conversations.list"] != 4 {
+		t.Errorf("Expected a refresh after the miss interval, got %d list calls", calls["/conversations.list"])
+	}
+	if _, err := notifier.ResolveChannel(ctx, "#alerts"); err == nil || !strings.Contains(err.Error(), "not a member") {
+		t.Errorf("Expected not a member error in strict mode, got %v", err)
+	}
+
+	notifier.autoJoinChannels = true
+	if id, err := notifier.ResolveChannel(ctx, "alerts"); err != nil || id != "C2" || joined != "C2" {
+		t.Errorf("Expected to join C2, got %s, %v (joined %s)", id, err, joined)
+	}
+	if _, err := notifier.ResolveChannel(ctx, "#secret"); err == nil {
+		t.Error("Expected error for private channel the bot is not in")
+	}
+}
+
+func TestSlackResolveChannelWithoutScope(t *testing.T) {
+	var lists, posted int32
+	var channel atomic.Value
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+
+		switch r.URL.Path {
+		case "/conversations.list":
+			atomic.AddInt32(&lists, 1)
+			<-release
+			_, _ = w.Write([]byte(`{"ok":false,"error":"missing_scope"}`))
+		case "/chat.postMessage":
+			atomic.AddInt32(&posted, 1)
+			channel.Store(r.FormValue("channel"))
+			_, _ = w.Write([]byte(`{"ok":true,"channel":"C1","ts":"1.0"}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(&SlackConfig{Token: "xoxb-test", DefaultChannel: "#general", APIURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	// Concurrent sends share one conversations.list call, then fall back to the channel name
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := notifier.Send(context.Background(), "hello"); err != nil {
+				t.Errorf("Send failed: %v", err)
+			}
+		}()
+	}
+	for atomic.LoadInt32(&lists) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if n := atomic.LoadInt32(&lists); n != 1 {
+		t.Errorf("Expected one shared conversations.list call, got %d", n)
+	}
+	if atomic.LoadInt32(&posted) != 5 || channel.Load() != "#general" {
+		t.Errorf("Expected 5 posts to #general, got %d to %v", posted, channel.Load())
+	}
+
+	// The failed lookup is not retried on every send
+	if err := notifier.Send(context.Background(), "again"); err != nil {
+		t.Errorf("Send failed: %v", err)
+	}
+	if n := atomic.LoadInt32(&lists); n != 1 {
+		t.Errorf("Expected the failed lookup to be throttled, got %d list calls", n)
+	}
+
+	notifier.strictChannels = true
+	notifier.mu.Lock()
+	notifier.channelsTried = time.Time{}
+	notifier.mu.Unlock()
+	if _, err := notifier.ResolveChannel(context.Background(), "#general"); err == nil || !strings.Contains(err.Error(), "missing_scope") {
+		t.Errorf("Expected missing_scope error in strict mode, got %v", err)
+	}
+}
+
+func TestSlackReactionsAndPins(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			_, _ = w.Write([]byte(`{"ok":true,"channel":"C1","ts":"1700000000.000100"}`))
+			return
+		case "/reactions.remove":
+			_, _ = w.Write([]byte(`{"ok":false,"error":"no_reaction"}`))
+		case "/pins.remove":
+			_, _ = w.Write([]byte(`{"ok":false,"error":"message_not_found"}`))
+		default:
+			_, _ = w.Write([]byte(`{"ok":true}`))
+		}
+		calls = append(calls, r.URL.Path+" "+r.FormValue("channel")+" "+r.FormValue("timestamp")+" "+r.FormValue("name"))
+	}))
+	defer server.Close()
+
+	notifier, err := NewSlackNotifier(&SlackConfig{Token: "xoxb-test", DefaultChannel: "C1", APIURL: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	ctx := context.Background()
+
+	receipt, err := notifier.Post(ctx, &Message{Text: "Database is down"})
+	if err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	if receipt.Channel != "C1" || receipt.Timestamp != "1700000000.000100" {
+		t.Fatalf("Unexpected receipt %+v", receipt)
+	}
+
+	if err := notifier.AddReaction(ctx, receipt, ":eyes:"); err != nil {
+		t.Errorf("AddReaction failed: %v", err)
+	}
+	if err := notifier.RemoveReaction(ctx, receipt, "eyes"); err != nil {
+		t.Errorf("Expected missing reaction to be ignored, got %v", err)
+	}
+	if err := notifier.Pin(ctx, receipt); err != nil {
+		t.Errorf("Pin failed: %v", err)
+	}
+	if err := notifier.Unpin(ctx, receipt); err == nil || !strings.Contains(err.Error(), "message_not_found") {
+		t.Errorf("Expected message_not_found error, got %v", err)
+	}
+
+	want := []string{
+		"/reactions.add C1 1700000000.000100 eyes",
+		"/reactions.remove C1 1700000000.000100 eyes",
+		"/pins.add C1 1700000000.000100 ",
+		"/pins.remove C1 1700000000.000100 ",
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected calls:\n%s", strings.Join(calls, "\n"))
+	}
+
+	if err := notifier.Pin(ctx, &SlackReceipt{Channel: "C1"}); err == nil {
+		t.Error("Expected error for a receipt without a timestamp")
+	}
+}
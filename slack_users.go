@@ -0,0 +1,175 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// SendEphemeral sends a message to a channel that is only visible to the given user.
+// The user must be a member of the channel.
+func (s *SlackNotifier) SendEphemeral(ctx context.Context, channel, userID string, msg *Message) error {
+	if s.client == nil {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  "slack client not initialized",
+		}
+	}
+
+	if msg.Text == "" {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  "message text is required",
+		}
+	}
+
+	if userID == "" {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  "user ID is required",
+		}
+	}
+
+	if channel == "" {
+		channel = msg.Channel
+	}
+	if channel == "" {
+		channel = s.defaultChannel
+	}
+	if channel == "" {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  "channel is required",
+		}
+	}
+
+	channel, err := s.ResolveChannel(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.client.PostEphemeralContext(ctx, channel, userID, s.messageOptions(msg)...); err != nil {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  "failed to send ephemeral message",
+			Err:      err,
+		}
+	}
+
+	return nil
+}
+
+// SendDirectByEmail sends a direct message to the user with the given email address.
+// msg.Channel is ignored.
+func (s *SlackNotifier) SendDirectByEmail(ctx context.Context, email string, msg *Message) error {
+	if msg.Text == "" {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  "message text is required",
+		}
+	}
+
+	userID, err := s.LookupUserByEmail(ctx, email)
+	if err != nil {
+		return err
+	}
+
+	channel, err := s.OpenDirectMessage(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := s.client.PostMessageContext(ctx, channel, s.messageOptions(msg)...); err != nil {
+		return &NotificationError{
+			Provider: "slack",
+			Message:  "failed to send direct message",
+			Err:      err,
+		}
+	}
+
+	return nil
+}
+
+// LookupUserByEmail returns the Slack user ID for an email address.
+// Results are cached for the lifetime of the notifier.
+func (s *SlackNotifier) LookupUserByEmail(ctx context.Context, email string) (string, error) {
+	if s.client == nil {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  "slack client not initialized",
+		}
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  "email is required",
+		}
+	}
+
+	s.mu.Lock()
+	userID, ok := s.userIDs[email]
+	s.mu.Unlock()
+	if ok {
+		return userID, nil
+	}
+
+	user, err := s.client.GetUserByEmailContext(ctx, email)
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  fmt.Sprintf("failed to look up user %s", email),
+			Err:      err,
+		}
+	}
+
+	s.mu.Lock()
+	if s.userIDs == nil {
+		s.userIDs = make(map[string]string)
+	}
+	s.userIDs[email] = user.ID
+	s.mu.Unlock()
+
+	return user.ID, nil
+}
+
+// OpenDirectMessage opens (or reuses) a direct message conversation with a user and returns its channel ID.
+// Results are cached for the lifetime of the notifier.
+func (s *SlackNotifier) OpenDirectMessage(ctx context.Context, userID string) (string, error) {
+	if s.client == nil {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  "slack client not initialized",
+		}
+	}
+
+	s.mu.Lock()
+	channelID, ok := s.dmChannels[userID]
+	s.mu.Unlock()
+	if ok {
+		return channelID, nil
+	}
+
+	channel, _, _, err := s.client.OpenConversationContext(ctx, &slack.OpenConversationParameters{
+		Users: []string{userID},
+	})
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "slack",
+			Message:  fmt.Sprintf("failed to open direct message with %s", userID),
+			Err:      err,
+		}
+	}
+
+	s.mu.Lock()
+	if s.dmChannels == nil {
+		s.dmChannels = make(map[string]string)
+	}
+	s.dmChannels[userID] = channel.ID
+	s.mu.Unlock()
+
+	return channel.ID, nil
+}
@@ -0,0 +1,297 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// snsReservedMetadata lists metadata keys that map to Publish parameters rather than message attributes
+var snsReservedMetadata = map[string]bool{
+	"message_group_id":         true,
+	"message_deduplication_id": true,
+	"message_structure":        true,
+}
+
+// SNSNotifier publishes notifications to AWS SNS topics and phone numbers
+type SNSNotifier struct {
+	name         string
+	credentials  awsCredentials
+	region       string
+	endpoint     string
+	defaultTopic string
+	client       *http.Client
+}
+
+// SNSConfig holds configuration for AWS SNS notifications
+type SNSConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "sns")
+	Name string
+
+	// Region is the AWS region (optional, defaults to AWS_REGION)
+	Region string
+
+	// AccessKeyID and SecretAccessKey are the AWS credentials
+	// (optional, default to AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY)
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// SessionToken is the session token for temporary credentials (optional)
+	SessionToken string
+
+	// DefaultTarget is the default topic ARN or E.164 phone number (optional)
+	DefaultTarget string
+
+	// Endpoint overrides the SNS endpoint (optional, for testing or local stand-ins)
+	Endpoint string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type snsPublishResponse struct {
+	MessageID string `xml:"PublishResult>MessageId"`
+}
+
+type snsErrorResponse struct {
+	Code    string `xml:"Error>Code"`
+	Message string `xml:"Error>Message"`
+}
+
+// NewSNSNotifier creates a new AWS SNS notifier
+func NewSNSNotifier(config SNSConfig) (*SNSNotifier, error) {
+	region := resolveAWSRegion(config.Region)
+	if region == "" {
+		return nil, &NotificationError{
+			Provider: "sns",
+			Message:  "region is required",
+		}
+	}
+
+	credentials := resolveAWSCredentials(config.AccessKeyID, config.SecretAccessKey, config.SessionToken)
+	if credentials.AccessKeyID == "" || credentials.SecretAccessKey == "" {
+		return nil, &NotificationError{
+			Provider: "sns",
+			Message:  "AWS credentials are required",
+		}
+	}
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://sns.%s.amazonaws.com", region)
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "sns"
+	}
+
+	return &SNSNotifier{
+		name:         name,
+		credentials:  credentials,
+		region:       region,
+		endpoint:     strings.TrimRight(endpoint, "/") + "/",
+		defaultTopic: config.DefaultTarget,
+		client:       newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (s *SNSNotifier) Name() string {
+	return s.name
+}
+
+// Send publishes a simple text message to the default target
+func (s *SNSNotifier) Send(ctx context.Context, message string) error {
+	return s.SendWithOptions(ctx, &Message{
+		Text:    message,
+		Channel: s.defaultTopic,
+	})
+}
+
+// SendWithOptions publishes a message.
+// Channel is a topic ARN or phone number, Title maps to Subject and Metadata to
+// message attributes; "message_group_id" and "message_deduplication_id" are used
+// for FIFO topics.
+func (s *SNSNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	_, err := s.Publish(ctx, msg)
+	return err
+}
+
+// Publish publishes a message and returns the SNS message ID
+func (s *SNSNotifier) Publish(ctx context.Context, msg *Message) (string, error) {
+	if msg.Text == "" {
+		return "", &NotificationError{
+			Provider: "sns",
+			Message:  "message text is required",
+		}
+	}
+
+	text := msg.Text
+	if len(msg.Attachments) > 0 {
+		text += "\n" + plainTextAttachments(msg.Attachments)
+	}
+
+	params := url.Values{}
+	params.Set("Message", text)
+
+	if msg.Title != "" {
+		params.Set("Subject", snsSubject(msg.Title))
+	}
+
+	if groupID := metadataString(msg, "message_group_id"); groupID != "" {
+		params.Set("MessageGroupId", groupID)
+	}
+	if dedupID := metadataString(msg, "message_deduplication_id"); dedupID != "" {
+		params.Set("MessageDeduplicationId", dedupID)
+	}
+	if structure := metadataString(msg, "message_structure"); structure != "" {
+		params.Set("MessageStructure", structure)
+	}
+
+	keys := make([]string, 0, len(msg.Metadata))
+	for key := range msg.Metadata {
+		if !snsReservedMetadata[key] {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for i, key := range keys {
+		prefix := fmt.Sprintf("MessageAttributes.entry.%d.", i+1)
+		dataType, value := snsAttributeValue(msg.Metadata[key])
+		params.Set(prefix+"Name", key)
+		params.Set(prefix+"Value.DataType", dataType)
+		params.Set(prefix+"Value.StringValue", value)
+	}
+
+	return s.publish(ctx, msg.Channel, params)
+}
+
+// SendRichMessage publishes with raw Publish parameters.
+// blocks must be a map[string]string of Publish API parameters; channel, if set,
+// is used as the target.
+func (s *SNSNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	raw, ok := blocks.(map[string]string)
+	if !ok {
+		return &NotificationError{
+			Provider: "sns",
+			Message:  "blocks must be of type map[string]string",
+		}
+	}
+
+	params := url.Values{}
+	for key, value := range raw {
+		params.Set(key, value)
+	}
+
+	if channel == "" && (params.Get("TopicArn") != "" || params.Get("TargetArn") != "" || params.Get("PhoneNumber") != "") {
+		_, err := s.call(ctx, params)
+		return err
+	}
+
+	_, err := s.publish(ctx, channel, params)
+	return err
+}
+
+// publish sets the target parameter and calls the Publish action
+func (s *SNSNotifier) publish(ctx context.Context, target string, params url.Values) (string, error) {
+	if target == "" {
+		target = s.defaultTopic
+	}
+
+	switch {
+	case target == "":
+		return "", &NotificationError{
+			Provider: "sns",
+			Message:  "topic ARN or phone number is required",
+		}
+	case strings.HasPrefix(target, "+"):
+		params.Set("PhoneNumber", target)
+	case strings.Contains(target, ":endpoint/"):
+		params.Set("TargetArn", target)
+	default:
+		params.Set("TopicArn", target)
+	}
+
+	return s.call(ctx, params)
+}
+
+// call sends a signed Publish request and returns the message ID
+func (s *SNSNotifier) call(ctx context.Context, params url.Values) (string, error) {
+	params.Set("Action", "Publish")
+	params.Set("Version", "2010-03-31")
+	body := []byte(params.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", &NotificationError{
+			Provider: "sns",
+			Message:  "failed to create request",
+			Err:      err,
+		}
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	signV4(req, body, s.credentials, s.region, "sns", time.Now())
+
+	status, respBody, err := doRequest(s.client, "sns", req)
+	if err != nil {
+		return "", err
+	}
+
+	if status != http.StatusOK {
+		var result snsErrorResponse
+		if xml.Unmarshal(respBody, &result) == nil && result.Code != "" {
+			return "", &NotificationError{
+				Provider: "sns",
+				Message:  fmt.Sprintf("API returned error %s: %s", result.Code, result.Message),
+			}
+		}
+		return "", &NotificationError{
+			Provider: "sns",
+			Message:  fmt.Sprintf("API request failed with status %d: %s", status, string(respBody)),
+		}
+	}
+
+	var result snsPublishResponse
+	if err := xml.Unmarshal(respBody, &result); err != nil {
+		return "", &NotificationError{
+			Provider: "sns",
+			Message:  "failed to parse response",
+			Err:      err,
+		}
+	}
+
+	return result.MessageID, nil
+}
+
+// snsSubject makes a title usable as a Subject: single line, at most 100 characters
+func snsSubject(title string) string {
+	return truncate(strings.Join(strings.Fields(title), " "), 100)
+}
+
+// snsAttributeValue returns the SNS data type and string value for a metadata value
+func snsAttributeValue(value interface{}) (string, string) {
+	switch v := value.(type) {
+	case int:
+		return "Number", strconv.Itoa(v)
+	case int64:
+		return "Number", strconv.FormatInt(v, 10)
+	case float64:
+		return "Number", strconv.FormatFloat(v, 'f', -1, 64)
+	case []string:
+		// Go-quoted strings aren't always valid JSON (e.g., \x escapes), so marshal the array
+		data, _ := json.Marshal(v)
+		return "String.Array", string(data)
+	default:
+		return "String", fmt.Sprintf("%v", v)
+	}
+}
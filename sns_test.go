@@ -0,0 +1,137 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignV4(t *testing.T) {
+	// get-vanilla case from the AWS Signature Version 4 test suite
+	req, err := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+
+	creds := awsCredentials{
+		AccessKeyID:     "AKIDEXAMPLE",
+		SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY",
+	}
+	signV4(req, nil, creds, "us-east-1", "service", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, " +
+		"SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31"
+	if req.Header.Get("Authorization") != expected {
+		t.Errorf("Unexpected Authorization header:\n got: %s\nwant: %s", req.Header.Get("Authorization"), expected)
+	}
+}
+
+func TestSNSSubject(t *testing.T) {
+	if subject := snsSubject("Build\nfailed  on main"); subject != "Build failed on main" {
+		t.Errorf("Expected a single-line subject, got %q", subject)
+	}
+
+	if subject := snsSubject(strings.Repeat("ß", 150)); subject != strings.Repeat("ß", 97)+"..." {
+		t.Errorf("Expected subject truncated to 100 characters, got %q", subject)
+	}
+}
+
+func TestSNSAttributeValue(t *testing.T) {
+	dataType, value := snsAttributeValue([]string{"ß", "tab\there", "\x00"})
+	if dataType != "String.Array" {
+		t.Errorf("Expected String.Array, got %s", dataType)
+	}
+
+	var items []string
+	if err := json.Unmarshal([]byte(value), &items); err != nil {
+		t.Fatalf("Expected a JSON array, got %s: %v", value, err)
+	}
+	if len(items) != 3 || items[0] != "ß" || items[1] != "tab\there" || items[2] != "\x00" {
+		t.Errorf("Array did not round-trip: %q", items)
+	}
+}
+
+func TestNewSNSNotifier(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+
+	_, err := NewSNSNotifier(SNSConfig{Region: "us-east-1"})
+	if err == nil {
+		t.Error("Expected error when credentials are missing")
+	}
+
+	notifier, err := NewSNSNotifier(SNSConfig{Region: "us-east-1", AccessKeyID: "AKID", SecretAccessKey: "secret"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "sns" {
+		t.Errorf("Expected name 'sns', got '%s'", notifier.Name())
+	}
+}
+
+func TestSNSPublishFIFO(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") {
+			t.Errorf("Unexpected Authorization header: %s", r.Header.Get("Authorization"))
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("Failed to parse form: %v", err)
+		}
+		form = map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		_, _ = w.Write([]byte(`<PublishResponse><PublishResult><MessageId>msg-1</MessageId></PublishResult></PublishResponse>`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewSNSNotifier(SNSConfig{
+		Region:          "us-east-1",
+		AccessKeyID:     "AKID",
+		SecretAccessKey: "secret",
+		Endpoint:        server.URL,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	messageID, err := notifier.Publish(context.Background(), &Message{
+		Title:   "Order\ncreated",
+		Text:    "Order 42 was created",
+		Channel: "arn:aws:sns:us-east-1:123456789012:orders.fifo",
+		Metadata: map[string]interface{}{
+			"message_group_id":         "orders",
+			"message_deduplication_id": "order-42",
+			"order_id":                 42,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if messageID != "msg-1" {
+		t.Errorf("Expected message ID 'msg-1', got '%s'", messageID)
+	}
+
+	expected := map[string]string{
+		"Action":                         "Publish",
+		"TopicArn":                       "arn:aws:sns:us-east-1:123456789012:orders.fifo",
+		"Subject":                        "Order created",
+		"MessageGroupId":                 "orders",
+		"MessageDeduplicationId":         "order-42",
+		"MessageAttributes.entry.1.Name": "order_id",
+		"MessageAttributes.entry.1.Value.DataType":    "Number",
+		"MessageAttributes.entry.1.Value.StringValue": "42",
+	}
+	for key, value := range expected {
+		if form[key] != value {
+			t.Errorf("Expected %s=%q, got %q", key, value, form[key])
+		}
+	}
+}
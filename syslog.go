@@ -0,0 +1,442 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Syslog facilities (RFC 5424 section 6.2.1)
+const (
+	SyslogFacilityUser   = 1
+	SyslogFacilityDaemon = 3
+	SyslogFacilityLocal0 = 16
+	SyslogFacilityLocal1 = 17
+	SyslogFacilityLocal2 = 18
+	SyslogFacilityLocal3 = 19
+	SyslogFacilityLocal4 = 20
+	SyslogFacilityLocal5 = 21
+	SyslogFacilityLocal6 = 22
+	SyslogFacilityLocal7 = 23
+)
+
+// syslogFraming is how messages are delimited on the connection
+type syslogFraming int
+
+const (
+	// syslogFramingNone sends one message per datagram
+	syslogFramingNone syslogFraming = iota
+
+	// syslogFramingOctetCounting prefixes messages with their length (RFC 6587, RFC 5425)
+	syslogFramingOctetCounting
+
+	// syslogFramingNUL terminates messages with a NUL byte, as glibc's syslog() does on
+	// local stream sockets, so multi-line messages stay intact
+	syslogFramingNUL
+)
+
+// syslogTimestampFormat is RFC 3339 with microseconds; RFC 5424 allows at most 6 fractional digits
+const syslogTimestampFormat = "2006-01-02T15:04:05.000000Z07:00"
+
+// syslogSocketPaths are the local syslog sockets tried when no address is configured
+var syslogSocketPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// SyslogNotifier sends notifications to a syslog server using RFC 5424
+type SyslogNotifier struct {
+	name      string
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	facility  int
+	hostname  string
+	appName   string
+	msgID     string
+	sdID      string
+	timeout   time.Duration
+
+	mu      sync.Mutex
+	conn    net.Conn
+	framing syslogFraming
+}
+
+// SyslogConfig holds configuration for syslog notifications
+type SyslogConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "syslog")
+	Name string
+
+	// Network is "udp", "tcp", "tls" or "unix" (optional, defaults to the local unix socket)
+	Network string
+
+	// Address is the server address, or the socket path for "unix" (optional for "unix")
+	Address string
+
+	// TLSConfig is used when Network is "tls" (optional)
+	TLSConfig *tls.Config
+
+	// Facility is the syslog facility (optional, defaults to SyslogFacilityUser)
+	Facility int
+
+	// Hostname is the HOSTNAME header field (optional, defaults to os.Hostname)
+	Hostname string
+
+	// AppName is the APP-NAME header field (optional, defaults to the program name)
+	AppName string
+
+	// MsgID is the MSGID header field (optional)
+	MsgID string
+
+	// StructuredDataID is the SD-ID used for metadata (optional, defaults to "notify@32473")
+	StructuredDataID string
+
+	// Timeout for dialing and writing (optional, defaults to 30s)
+	Timeout time.Duration
+}
+
+// NewSyslogNotifier creates a new syslog notifier.
+// The connection is opened on the first notification and re-established after write errors.
+func NewSyslogNotifier(config SyslogConfig) (*SyslogNotifier, error) {
+	network := config.Network
+	switch network {
+	case "":
+		network = "unix"
+	case "udp", "tcp", "tls", "unix":
+	default:
+		return nil, &NotificationError{
+			Provider: "syslog",
+			Message:  fmt.Sprintf("unsupported network %q", network),
+		}
+	}
+
+	if network != "unix" && config.Address == "" {
+		return nil, &NotificationError{
+			Provider: "syslog",
+			Message:  "address is required",
+		}
+	}
+
+	facility := config.Facility
+	if facility == 0 {
+		facility = SyslogFacilityUser
+	}
+	if facility < 0 || facility > 23 {
+		return nil, &NotificationError{
+			Provider: "syslog",
+			Message:  fmt.Sprintf("invalid facility %d", facility),
+		}
+	}
+
+	hostname := config.Hostname
+	if hostname == "" {
+		hostname, _ = os.Hostname()
+	}
+
+	appName := config.AppName
+	if appName == "" {
+		appName = filepath.Base(os.Args[0])
+	}
+
+	sdID := config.StructuredDataID
+	if sdID == "" {
+		sdID = "notify@32473"
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "syslog"
+	}
+
+	return &SyslogNotifier{
+		name:      name,
+		network:   network,
+		address:   config.Address,
+		tlsConfig: config.TLSConfig,
+		facility:  facility,
+		hostname:  hostname,
+		appName:   appName,
+		msgID:     config.MsgID,
+		sdID:      sdID,
+		timeout:   timeout,
+	}, nil
+}
+
+// Name returns the name of the provider
+func (s *SyslogNotifier) Name() string {
+	return s.name
+}
+
+// Send sends a simple text message
+func (s *SyslogNotifier) Send(ctx context.Context, message string) error {
+	return s.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Priority sets the severity, Metadata becomes structured data and the
+// "msg_id" metadata key overrides the configured MSGID.
+func (s *SyslogNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	msgID := s.msgID
+	if id := metadataString(msg, "msg_id"); id != "" {
+		msgID = id
+	}
+
+	return s.write(ctx, s.format(syslogSeverity(msg.Priority), msgID, msg.Metadata, logText(msg)))
+}
+
+// SendRichMessage sends blocks as the message body.
+// Strings are sent as-is and anything else is encoded as JSON.
+func (s *SyslogNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	text, err := logBlocks(blocks)
+	if err != nil {
+		return &NotificationError{
+			Provider: "syslog",
+			Message:  "failed to marshal blocks",
+			Err:      err,
+		}
+	}
+
+	var metadata map[string]interface{}
+	if channel != "" {
+		metadata = map[string]interface{}{"channel": channel}
+	}
+
+	return s.write(ctx, s.format(syslogSeverity(""), s.msgID, metadata, text))
+}
+
+// Close closes the connection to the syslog server
+func (s *SyslogNotifier) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// format renders an RFC 5424 message
+func (s *SyslogNotifier) format(severity int, msgID string, metadata map[string]interface{}, text string) string {
+	return fmt.Sprintf("<%d>1 %s %s %s %d %s %s %s",
+		s.facility*8+severity,
+		time.Now().Format(syslogTimestampFormat),
+		syslogHeaderField(s.hostname, 255),
+		syslogHeaderField(s.appName, 48),
+		os.Getpid(),
+		syslogHeaderField(msgID, 32),
+		syslogStructuredData(s.sdID, metadata),
+		text)
+}
+
+// write sends a formatted message, reconnecting once if the connection was lost
+func (s *SyslogNotifier) write(ctx context.Context, message string) error {
+	if err := ctx.Err(); err != nil {
+		return &NotificationError{
+			Provider: "syslog",
+			Message:  "context done",
+			Err:      err,
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		if s.conn == nil {
+			if err = s.connect(ctx); err != nil {
+				return err
+			}
+		}
+
+		frame := message
+		switch s.framing {
+		case syslogFramingOctetCounting:
+			frame = fmt.Sprintf("%d %s", len(message), message)
+		case syslogFramingNUL:
+			frame = message + "\x00"
+		}
+
+		_ = s.conn.SetWriteDeadline(time.Now().Add(s.timeout))
+		if _, err = s.conn.Write([]byte(frame)); err == nil {
+			return nil
+		}
+
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	return &NotificationError{
+		Provider: "syslog",
+		Message:  "failed to write message",
+		Err:      err,
+	}
+}
+
+// connect dials the configured server
+func (s *SyslogNotifier) connect(ctx context.Context) error {
+	dialer := &net.Dialer{Timeout: s.timeout}
+
+	var conn net.Conn
+	var err error
+	switch s.network {
+	case "tls":
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", s.address)
+		s.framing = syslogFramingOctetCounting
+	case "tcp":
+		conn, err = dialer.DialContext(ctx, "tcp", s.address)
+		s.framing = syslogFramingOctetCounting
+	case "udp":
+		conn, err = dialer.DialContext(ctx, "udp", s.address)
+		s.framing = syslogFramingNone
+	default:
+		conn, s.framing, err = s.dialUnix(ctx, dialer)
+	}
+
+	if err != nil {
+		return &NotificationError{
+			Provider: "syslog",
+			Message:  "failed to connect",
+			Err:      err,
+		}
+	}
+
+	s.conn = conn
+	return nil
+}
+
+// dialUnix connects to a local socket, trying datagram before stream sockets
+func (s *SyslogNotifier) dialUnix(ctx context.Context, dialer *net.Dialer) (net.Conn, syslogFraming, error) {
+	paths := syslogSocketPaths
+	if s.address != "" {
+		paths = []string{s.address}
+	}
+
+	var lastErr error
+	for _, path := range paths {
+		for _, network := range []string{"unixgram", "unix"} {
+			conn, err := dialer.DialContext(ctx, network, path)
+			if err == nil {
+				if network == "unix" {
+					return conn, syslogFramingNUL, nil
+				}
+				return conn, syslogFramingNone, nil
+			}
+			lastErr = err
+		}
+	}
+
+	return nil, syslogFramingNone, lastErr
+}
+
+// syslogSeverity maps a message priority to a syslog severity
+func syslogSeverity(priority string) int {
+	switch priority {
+	case PriorityHigh:
+		return 2 // critical
+	case PriorityLow:
+		return 6 // informational
+	default:
+		return 5 // notice
+	}
+}
+
+// syslogHeaderField returns value as a printable header field, or NILVALUE if empty
+func syslogHeaderField(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, value)
+
+	if value == "" {
+		return "-"
+	}
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	return value
+}
+
+// syslogStructuredData renders metadata as a single SD-ELEMENT, or NILVALUE if empty
+func syslogStructuredData(sdID string, metadata map[string]interface{}) string {
+	keys := make([]string, 0, len(metadata))
+	for key := range metadata {
+		if syslogParamName(key) != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return "-"
+	}
+	sort.Strings(keys)
+
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`)
+
+	var sb strings.Builder
+	sb.WriteString("[")
+	sb.WriteString(sdID)
+	for _, key := range keys {
+		fmt.Fprintf(&sb, ` %s="%s"`, syslogParamName(key), escaper.Replace(fmt.Sprint(metadata[key])))
+	}
+	sb.WriteString("]")
+
+	return sb.String()
+}
+
+// syslogParamName strips characters not allowed in an SD-NAME
+func syslogParamName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || r == '=' || r == ']' || r == '"' {
+			return -1
+		}
+		return r
+	}, name)
+
+	if len(name) > 32 {
+		name = name[:32]
+	}
+	return name
+}
+
+// logText renders the title, text and attachments of a message as plain text
+func logText(msg *Message) string {
+	text := msg.Text
+	if msg.Title != "" {
+		text = msg.Title + ": " + text
+	}
+	if len(msg.Attachments) > 0 {
+		text += "\n" + plainTextAttachments(msg.Attachments)
+	}
+	return text
+}
+
+// logBlocks renders rich message blocks as text, encoding non-strings as JSON
+func logBlocks(blocks interface{}) (string, error) {
+	if text, ok := blocks.(string); ok {
+		return text, nil
+	}
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
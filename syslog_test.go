@@ -0,0 +1,195 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// syslogTimestampPattern matches an RFC 5424 TIMESTAMP
+var syslogTimestampPattern = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d{1,6})?(Z|[+-]\d{2}:\d{2})$`)
+
+func TestNewSyslogNotifier(t *testing.T) {
+	_, err := NewSyslogNotifier(SyslogConfig{Network: "tcp"})
+	if err == nil {
+		t.Error("Expected error when address is missing")
+	}
+
+	_, err = NewSyslogNotifier(SyslogConfig{Network: "sctp", Address: "localhost:514"})
+	if err == nil {
+		t.Error("Expected error for unsupported network")
+	}
+
+	notifier, err := NewSyslogNotifier(SyslogConfig{Network: "udp", Address: "localhost:514"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "syslog" {
+		t.Errorf("Expected name 'syslog', got '%s'", notifier.Name())
+	}
+}
+
+func TestSyslogUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer conn.Close()
+
+	notifier, err := NewSyslogNotifier(SyslogConfig{
+		Network:  "udp",
+		Address:  conn.LocalAddr().String(),
+		Facility: SyslogFacilityLocal0,
+		Hostname: "web-1",
+		AppName:  "billing",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Title:    "Payment failed",
+		Text:     "card declined",
+		Priority: PriorityHigh,
+		Metadata: map[string]interface{}{"msg_id": "PAY", "order": `A"1]`},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	buf := make([]byte, 2048)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("Failed to read datagram: %v", err)
+	}
+	line := string(buf[:n])
+
+	// local0 (16) * 8 + critical (2)
+	if !strings.HasPrefix(line, "<130>1 ") {
+		t.Errorf("Unexpected PRI/version: %s", line)
+	}
+	if fields := strings.Fields(line); len(fields) < 2 || !syslogTimestampPattern.MatchString(fields[1]) {
+		t.Errorf("Expected an RFC 5424 timestamp with at most 6 fractional digits: %s", line)
+	}
+	if !strings.Contains(line, " web-1 billing ") || !strings.Contains(line, " PAY ") {
+		t.Errorf("Unexpected header: %s", line)
+	}
+	if !strings.Contains(line, `[notify@32473 msg_id="PAY" order="A\"1\]"]`) {
+		t.Errorf("Unexpected structured data: %s", line)
+	}
+	if !strings.HasSuffix(line, " Payment failed: card declined") {
+		t.Errorf("Unexpected message: %s", line)
+	}
+}
+
+func TestSyslogTCPOctetCounting(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan string, 1)
+	go func() {
+		defer close(received)
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Each frame is "<length> <message>"
+		reader := bufio.NewReader(conn)
+		prefix, err := reader.ReadString(' ')
+		if err != nil {
+			t.Errorf("Failed to read length: %v", err)
+			return
+		}
+		length, err := strconv.Atoi(strings.TrimSpace(prefix))
+		if err != nil {
+			t.Errorf("Invalid length prefix %q", prefix)
+			return
+		}
+		frame := make([]byte, length)
+		if _, err := io.ReadFull(reader, frame); err != nil {
+			t.Errorf("Failed to read frame: %v", err)
+			return
+		}
+		received <- string(frame)
+	}()
+
+	notifier, err := NewSyslogNotifier(SyslogConfig{Network: "tcp", Address: listener.Addr().String()})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	if err := notifier.Send(context.Background(), "hello\nworld"); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	frame := <-received
+	// user (1) * 8 + notice (5)
+	if !strings.HasPrefix(frame, "<13>1 ") || !strings.HasSuffix(frame, " - hello\nworld") {
+		t.Errorf("Unexpected frame: %q", frame)
+	}
+}
+
+func TestSyslogUnixStreamFraming(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log.sock")
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan string, 2)
+	go func() {
+		defer close(received)
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Local daemons read NUL-terminated frames from stream sockets
+		reader := bufio.NewReader(conn)
+		for i := 0; i < 2; i++ {
+			frame, err := reader.ReadString(0)
+			if err != nil {
+				t.Errorf("Failed to read frame: %v", err)
+				return
+			}
+			received <- strings.TrimSuffix(frame, "\x00")
+		}
+	}()
+
+	notifier, err := NewSyslogNotifier(SyslogConfig{Network: "unix", Address: path})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+	defer notifier.Close()
+
+	for _, text := range []string{"hello\nworld", "second"} {
+		if err := notifier.Send(context.Background(), text); err != nil {
+			t.Fatalf("Send failed: %v", err)
+		}
+	}
+
+	if frame := <-received; !strings.HasPrefix(frame, "<13>1 ") || !strings.HasSuffix(frame, " - hello\nworld") {
+		t.Errorf("Unexpected first frame: %q", frame)
+	}
+	if frame := <-received; !strings.HasSuffix(frame, " - second") {
+		t.Errorf("Unexpected second frame: %q", frame)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 // TelegramNotifier sends notifications via Telegram Bot API
 type TelegramNotifier struct {
+	name      string
 	botToken  string
 	chatID    string
 	client    *http.Client
@@ -20,6 +21,9 @@ type TelegramNotifier struct {
 
 // TelegramConfig holds configuration for Telegram notifications
 type TelegramConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "telegram")
+	Name string
+
 	// BotToken is the Telegram Bot API token
 	BotToken string
 
@@ -61,7 +65,13 @@ func NewTelegramNotifier(config TelegramConfig) (*TelegramNotifier, error) {
 		parseMode = "Markdown"
 	}
 
+	name := config.Name
+	if name == "" {
+		name = "telegram"
+	}
+
 	return &TelegramNotifier{
+		name:      name,
 		botToken:  config.BotToken,
 		chatID:    config.ChatID,
 		client:    client,
@@ -71,7 +81,7 @@ func NewTelegramNotifier(config TelegramConfig) (*TelegramNotifier, error) {
 
 // Name returns the name of the provider
 func (t *TelegramNotifier) Name() string {
-	return "telegram"
+	return t.name
 }
 
 // Send sends a simple text message
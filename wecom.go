@@ -0,0 +1,165 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// WeComNotifier sends notifications to WeCom (WeChat Work) group robots
+type WeComNotifier struct {
+	name       string
+	webhookURL string
+	client     *http.Client
+}
+
+// WeComConfig holds configuration for WeCom notifications
+type WeComConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "wecom")
+	Name string
+
+	// WebhookURL is the group robot webhook URL (including key)
+	WebhookURL string
+
+	// HTTPClient allows custom HTTP client (optional)
+	HTTPClient *http.Client
+}
+
+type weComResponse struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// NewWeComNotifier creates a new WeCom notifier
+func NewWeComNotifier(config WeComConfig) (*WeComNotifier, error) {
+	if config.WebhookURL == "" {
+		return nil, &NotificationError{
+			Provider: "wecom",
+			Message:  "webhook URL is required",
+		}
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "wecom"
+	}
+
+	return &WeComNotifier{
+		name:       name,
+		webhookURL: config.WebhookURL,
+		client:     newHTTPClient(config.HTTPClient),
+	}, nil
+}
+
+// Name returns the name of the provider
+func (w *WeComNotifier) Name() string {
+	return w.name
+}
+
+// Send sends a simple text message
+func (w *WeComNotifier) Send(ctx context.Context, message string) error {
+	return w.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Messages with a title or attachments are sent as markdown. Metadata keys
+// "mention_users", "mention_mobiles" and "mention_all" @mention group members
+// (mobiles are only supported for plain text messages).
+func (w *WeComNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if msg.Text == "" {
+		return &NotificationError{
+			Provider: "wecom",
+			Message:  "message text is required",
+		}
+	}
+
+	users := metadataStrings(msg, "mention_users")
+	mobiles := metadataStrings(msg, "mention_mobiles")
+	if mentionAll, _ := msg.Metadata["mention_all"].(bool); mentionAll {
+		users = append(users, "@all")
+	}
+
+	if msg.Title == "" && len(msg.Attachments) == 0 {
+		return w.post(ctx, msg.Channel, map[string]interface{}{
+			"msgtype": "text",
+			"text": map[string]interface{}{
+				"content":               msg.Text,
+				"mentioned_list":        users,
+				"mentioned_mobile_list": mobiles,
+			},
+		})
+	}
+
+	var sb strings.Builder
+	if msg.Title != "" {
+		color := "info"
+		if msg.Priority == PriorityHigh {
+			color = "warning"
+		}
+		fmt.Fprintf(&sb, "### <font color=\"%s\">%s</font>\n", color, msg.Title)
+	}
+	sb.WriteString(msg.Text)
+	if len(msg.Attachments) > 0 {
+		sb.WriteString("\n")
+		sb.WriteString(markdownAttachments(msg.Attachments))
+	}
+
+	// Markdown messages mention users inline with <@userid>
+	for _, user := range users {
+		if user == "@all" {
+			continue
+		}
+		fmt.Fprintf(&sb, " <@%s>", user)
+	}
+
+	return w.post(ctx, msg.Channel, map[string]interface{}{
+		"msgtype":  "markdown",
+		"markdown": map[string]string{"content": sb.String()},
+	})
+}
+
+// SendRichMessage sends a raw robot message.
+// blocks must be a map[string]interface{} with "msgtype" and the matching content
+// (e.g., news or template_card); channel, if set, overrides the webhook URL.
+func (w *WeComNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	payload, ok := blocks.(map[string]interface{})
+	if !ok {
+		return &NotificationError{
+			Provider: "wecom",
+			Message:  "blocks must be of type map[string]interface{}",
+		}
+	}
+
+	if _, ok := payload["msgtype"]; !ok {
+		return &NotificationError{
+			Provider: "wecom",
+			Message:  "msgtype is required",
+		}
+	}
+
+	return w.post(ctx, channel, payload)
+}
+
+// post sends payload to the webhook and checks the error code
+func (w *WeComNotifier) post(ctx context.Context, webhookURL string, payload interface{}) error {
+	if webhookURL == "" {
+		webhookURL = w.webhookURL
+	}
+
+	var result weComResponse
+	if err := sendJSON(ctx, w.client, "wecom", http.MethodPost, webhookURL, nil, payload, &result); err != nil {
+		return err
+	}
+
+	if result.ErrCode != 0 {
+		return &NotificationError{
+			Provider: "wecom",
+			Message:  fmt.Sprintf("API returned error %d: %s", result.ErrCode, result.ErrMsg),
+		}
+	}
+
+	return nil
+}
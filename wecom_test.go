@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewWeComNotifier(t *testing.T) {
+	_, err := NewWeComNotifier(WeComConfig{})
+	if err == nil {
+		t.Error("Expected error when webhook URL is missing")
+	}
+
+	notifier, err := NewWeComNotifier(WeComConfig{WebhookURL: "https://qyapi.weixin.qq.com/cgi-bin/webhook/send?key=x"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "wecom" {
+		t.Errorf("Expected name 'wecom', got '%s'", notifier.Name())
+	}
+}
+
+func TestWeComTextMentions(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("key") != "robot-key" {
+			t.Errorf("Expected robot key to be kept, got %s", r.URL.RawQuery)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewWeComNotifier(WeComConfig{WebhookURL: server.URL + "/cgi-bin/webhook/send?key=robot-key"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Text: "Disk almost full",
+		Metadata: map[string]interface{}{
+			"mention_users":   []string{"zhangsan"},
+			"mention_mobiles": []string{"13800000000"},
+			"mention_all":     true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if body["msgtype"] != "text" {
+		t.Fatalf("Expected text message, got %v", body["msgtype"])
+	}
+
+	text := body["text"].(map[string]interface{})
+	if text["content"] != "Disk almost full" {
+		t.Errorf("Unexpected content: %v", text["content"])
+	}
+
+	users := text["mentioned_list"].([]interface{})
+	if len(users) != 2 || users[0] != "zhangsan" || users[1] != "@all" {
+		t.Errorf("Unexpected mentioned_list: %v", users)
+	}
+
+	mobiles := text["mentioned_mobile_list"].([]interface{})
+	if len(mobiles) != 1 || mobiles[0] != "13800000000" {
+		t.Errorf("Unexpected mentioned_mobile_list: %v", mobiles)
+	}
+}
+
+func TestWeComMarkdown(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode body: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewWeComNotifier(WeComConfig{WebhookURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Title:    "CPU high",
+		Text:     "CPU usage above 90%",
+		Priority: PriorityHigh,
+		Attachments: []Attachment{
+			{Fields: []Field{{Title: "Host", Value: "web-1"}}},
+		},
+		Metadata: map[string]interface{}{
+			"mention_users": []string{"zhangsan"},
+			"mention_all":   true,
+		},
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	if body["msgtype"] != "markdown" {
+		t.Fatalf("Expected markdown message, got %v", body["msgtype"])
+	}
+
+	content := body["markdown"].(map[string]interface{})["content"].(string)
+	if !strings.Contains(content, `<font color="warning">CPU high</font>`) {
+		t.Errorf("Expected warning title for high priority, got %s", content)
+	}
+	if !strings.Contains(content, "**Host:** web-1") {
+		t.Errorf("Expected attachment fields, got %s", content)
+	}
+	if !strings.Contains(content, "<@zhangsan>") || strings.Contains(content, "<@@all>") {
+		t.Errorf("Expected inline user mention only, got %s", content)
+	}
+}
+
+func TestWeComAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"errcode":93000,"errmsg":"invalid webhook url"}`))
+	}))
+	defer server.Close()
+
+	notifier, err := NewWeComNotifier(WeComConfig{WebhookURL: server.URL})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.Send(context.Background(), "hello")
+	if err == nil || !strings.Contains(err.Error(), "93000") {
+		t.Errorf("Expected error with errcode 93000, got %v", err)
+	}
+}
+
+func TestWeComRichMessage(t *testing.T) {
+	notifier, err := NewWeComNotifier(WeComConfig{WebhookURL: "http://127.0.0.1"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if err := notifier.SendRichMessage(context.Background(), "", "not a map"); err == nil {
+		t.Error("Expected error for invalid blocks type")
+	}
+	if err := notifier.SendRichMessage(context.Background(), "", map[string]interface{}{"news": nil}); err == nil {
+		t.Error("Expected error when msgtype is missing")
+	}
+}
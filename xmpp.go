@@ -0,0 +1,607 @@
+package notify
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// XMPP namespaces used by the notifier
+const (
+	xmppNSClient   = "jabber:client"
+	xmppNSStream   = "http://etherx.jabber.org/streams"
+	xmppNSTLS      = "urn:ietf:params:xml:ns:xmpp-tls"
+	xmppNSSASL     = "urn:ietf:params:xml:ns:xmpp-sasl"
+	xmppNSBind     = "urn:ietf:params:xml:ns:xmpp-bind"
+	xmppNSMUC      = "http://jabber.org/protocol/muc"
+	xmppRoomPrefix = "muc:"
+)
+
+// XMPPNotifier sends notifications as XMPP chat messages or MUC groupchat messages.
+// Each notification opens a short-lived, TLS-protected client session.
+type XMPPNotifier struct {
+	name      string
+	username  string
+	domain    string
+	password  string
+	server    string
+	directTLS bool
+	tlsConfig *tls.Config
+	resource  string
+	nickname  string
+	defaultTo string
+	timeout   time.Duration
+}
+
+// XMPPConfig holds configuration for XMPP notifications
+type XMPPConfig struct {
+	// Name is the name the notifier is registered under (optional, defaults to "xmpp")
+	Name string
+
+	// JID is the bare JID of the sending account (e.g., alerts@example.com)
+	JID string
+
+	// Password is the account password (SASL PLAIN over TLS)
+	Password string
+
+	// Server is the host:port to connect to (optional, defaults to the SRV record or domain:5222)
+	Server string
+
+	// DirectTLS connects with TLS immediately instead of STARTTLS (optional, usually port 5223)
+	DirectTLS bool
+
+	// TLSConfig allows custom TLS settings (optional)
+	TLSConfig *tls.Config
+
+	// Resource is the resource to bind (optional, defaults to "notify")
+	Resource string
+
+	// Nickname used when joining rooms (optional, defaults to the JID local part)
+	Nickname string
+
+	// DefaultTo is the default recipient JID, or "muc:room@conference.example.com" for a room (optional)
+	DefaultTo string
+
+	// Timeout for the whole session (optional, defaults to 30s)
+	Timeout time.Duration
+}
+
+type xmppFeatures struct {
+	StartTLS   *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-tls starttls"`
+	Mechanisms []string  `xml:"urn:ietf:params:xml:ns:xmpp-sasl mechanisms>mechanism"`
+	Bind       *struct{} `xml:"urn:ietf:params:xml:ns:xmpp-bind bind"`
+}
+
+type xmppError struct {
+	Type      string `xml:"type,attr"`
+	Condition struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+type xmppIQ struct {
+	Type  string     `xml:"type,attr"`
+	ID    string     `xml:"id,attr"`
+	Error *xmppError `xml:"error"`
+}
+
+type xmppPresence struct {
+	From   string     `xml:"from,attr"`
+	Type   string     `xml:"type,attr"`
+	Error  *xmppError `xml:"error"`
+	Status []struct {
+		Code string `xml:"code,attr"`
+	} `xml:"http://jabber.org/protocol/muc#user x>status"`
+}
+
+// xmppSession is a single client connection
+type xmppSession struct {
+	conn    net.Conn
+	decoder *xml.Decoder
+}
+
+// NewXMPPNotifier creates a new XMPP notifier
+func NewXMPPNotifier(config XMPPConfig) (*XMPPNotifier, error) {
+	username, domain, ok := strings.Cut(config.JID, "@")
+	if !ok || username == "" || domain == "" {
+		return nil, &NotificationError{
+			Provider: "xmpp",
+			Message:  "a JID of the form user@domain is required",
+		}
+	}
+	domain, _, _ = strings.Cut(domain, "/")
+
+	if config.Password == "" {
+		return nil, &NotificationError{
+			Provider: "xmpp",
+			Message:  "password is required",
+		}
+	}
+
+	resource := config.Resource
+	if resource == "" {
+		resource = "notify"
+	}
+
+	nickname := config.Nickname
+	if nickname == "" {
+		nickname = username
+	}
+
+	timeout := config.Timeout
+	if timeout == 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	name := config.Name
+	if name == "" {
+		name = "xmpp"
+	}
+
+	return &XMPPNotifier{
+		name:      name,
+		username:  username,
+		domain:    domain,
+		password:  config.Password,
+		server:    config.Server,
+		directTLS: config.DirectTLS,
+		tlsConfig: config.TLSConfig,
+		resource:  resource,
+		nickname:  nickname,
+		defaultTo: config.DefaultTo,
+		timeout:   timeout,
+	}, nil
+}
+
+// Name returns the name of the provider
+func (x *XMPPNotifier) Name() string {
+	return x.name
+}
+
+// Send sends a simple text message
+func (x *XMPPNotifier) Send(ctx context.Context, message string) error {
+	return x.SendWithOptions(ctx, &Message{
+		Text: message,
+	})
+}
+
+// SendWithOptions sends a message with additional options.
+// Message.Channel is a comma-separated list of JIDs; rooms are prefixed with
+// "muc:" and joined before a groupchat message is sent.
+func (x *XMPPNotifier) SendWithOptions(ctx context.Context, msg *Message) error {
+	if msg.Text == "" {
+		return &NotificationError{
+			Provider: "xmpp",
+			Message:  "message text is required",
+		}
+	}
+
+	body := msg.Text
+	if msg.Title != "" {
+		body = msg.Title + "\n" + body
+	}
+	if len(msg.Attachments) > 0 {
+		body += "\n\n" + plainTextAttachments(msg.Attachments)
+	}
+
+	return x.deliver(ctx, msg.Channel, func(to, messageType string) string {
+		return fmt.Sprintf(`<message to="%s" type="%s" id="%s"><body>%s</body></message>`,
+			xmppEscape(to), messageType, xmppID(), xmppEscape(body))
+	})
+}
+
+// SendRichMessage sends a raw message stanza payload.
+// blocks must be a string of XML child elements (e.g., <body/> plus an XHTML-IM
+// <html/> element); it is wrapped in a <message/> addressed to each target.
+func (x *XMPPNotifier) SendRichMessage(ctx context.Context, channel string, blocks interface{}) error {
+	payload, ok := blocks.(string)
+	if !ok {
+		return &NotificationError{
+			Provider: "xmpp",
+			Message:  "blocks must be of type string",
+		}
+	}
+
+	return x.deliver(ctx, channel, func(to, messageType string) string {
+		return fmt.Sprintf(`<message to="%s" type="%s" id="%s">%s</message>`,
+			xmppEscape(to), messageType, xmppID(), payload)
+	})
+}
+
+// deliver opens a session and sends the stanza built for every target
+func (x *XMPPNotifier) deliver(ctx context.Context, channel string, stanza func(to, messageType string) string) error {
+	if channel == "" {
+		channel = x.defaultTo
+	}
+	targets := splitList(channel)
+	if len(targets) == 0 {
+		return &NotificationError{
+			Provider: "xmpp",
+			Message:  "recipient is required",
+		}
+	}
+
+	session, err := x.connect(ctx)
+	if err != nil {
+		return err
+	}
+	defer session.close()
+
+	for _, target := range targets {
+		if room, ok := strings.CutPrefix(target, xmppRoomPrefix); ok {
+			if err := x.joinRoom(session, room); err != nil {
+				return err
+			}
+			if err := session.write(stanza(room, "groupchat")); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := session.write(stanza(target, "chat")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// connect dials the server and negotiates TLS, authentication and resource binding
+func (x *XMPPNotifier) connect(ctx context.Context) (*xmppSession, error) {
+	deadline := time.Now().Add(x.timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+
+	dialer := &net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", x.serverAddress(ctx))
+	if err != nil {
+		return nil, &NotificationError{
+			Provider: "xmpp",
+			Message:  "failed to connect",
+			Err:      err,
+		}
+	}
+	_ = conn.SetDeadline(deadline)
+
+	session := &xmppSession{conn: conn}
+	if x.directTLS {
+		if session.conn, err = x.handshake(conn); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	if err := x.negotiate(session); err != nil {
+		session.conn.Close()
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// negotiate runs the stream setup from the initial stream header to resource binding
+func (x *XMPPNotifier) negotiate(session *xmppSession) error {
+	features, err := session.open(x.domain)
+	if err != nil {
+		return err
+	}
+
+	if _, isTLS := session.conn.(*tls.Conn); !isTLS {
+		if features.StartTLS == nil {
+			return &NotificationError{
+				Provider: "xmpp",
+				Message:  "server does not offer STARTTLS",
+			}
+		}
+
+		if err := session.write(`<starttls xmlns="` + xmppNSTLS + `"/>`); err != nil {
+			return err
+		}
+		element, err := session.next()
+		if err != nil {
+			return err
+		}
+		if element.Name.Local != "proceed" {
+			return &NotificationError{
+				Provider: "xmpp",
+				Message:  "STARTTLS was refused",
+			}
+		}
+		if session.conn, err = x.handshake(session.conn); err != nil {
+			return err
+		}
+		if features, err = session.open(x.domain); err != nil {
+			return err
+		}
+	}
+
+	if !containsString(features.Mechanisms, "PLAIN") {
+		return &NotificationError{
+			Provider: "xmpp",
+			Message:  fmt.Sprintf("server does not support SASL PLAIN (offered: %s)", strings.Join(features.Mechanisms, ", ")),
+		}
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte("\x00" + x.username + "\x00" + x.password))
+	if err := session.write(`<auth xmlns="` + xmppNSSASL + `" mechanism="PLAIN">` + credentials + `</auth>`); err != nil {
+		return err
+	}
+	element, err := session.next()
+	if err != nil {
+		return err
+	}
+	if element.Name.Local != "success" {
+		_ = session.decoder.Skip()
+		return &NotificationError{
+			Provider: "xmpp",
+			Message:  "authentication failed",
+		}
+	}
+
+	if features, err = session.open(x.domain); err != nil {
+		return err
+	}
+	if features.Bind == nil {
+		return &NotificationError{
+			Provider: "xmpp",
+			Message:  "server does not support resource binding",
+		}
+	}
+
+	bind := fmt.Sprintf(`<iq type="set" id="bind"><bind xmlns="%s"><resource>%s</resource></bind></iq>`, xmppNSBind, xmppEscape(x.resource))
+	if err := session.write(bind); err != nil {
+		return err
+	}
+	for {
+		element, err := session.next()
+		if err != nil {
+			return err
+		}
+		if element.Name.Local != "iq" {
+			_ = session.decoder.Skip()
+			continue
+		}
+
+		var iq xmppIQ
+		if err := session.decoder.DecodeElement(&iq, &element); err != nil {
+			return session.readError(err)
+		}
+		if iq.ID != "bind" {
+			continue
+		}
+		if iq.Type != "result" {
+			return &NotificationError{
+				Provider: "xmpp",
+				Message:  "resource binding failed" + iq.Error.describe(),
+			}
+		}
+		return nil
+	}
+}
+
+// joinRoom enters a MUC room and waits for the server to confirm the join
+func (x *XMPPNotifier) joinRoom(session *xmppSession, room string) error {
+	occupant := room + "/" + x.nickname
+	join := fmt.Sprintf(`<presence to="%s"><x xmlns="%s"><history maxstanzas="0"/></x></presence>`, xmppEscape(occupant), xmppNSMUC)
+	if err := session.write(join); err != nil {
+		return err
+	}
+
+	for {
+		element, err := session.next()
+		if err != nil {
+			return err
+		}
+		if element.Name.Local != "presence" {
+			_ = session.decoder.Skip()
+			continue
+		}
+
+		var presence xmppPresence
+		if err := session.decoder.DecodeElement(&presence, &element); err != nil {
+			return session.readError(err)
+		}
+		from := strings.ToLower(presence.From)
+		if from != strings.ToLower(room) && !strings.HasPrefix(from, strings.ToLower(room)+"/") {
+			continue
+		}
+		if presence.Type == "error" {
+			return &NotificationError{
+				Provider: "xmpp",
+				Message:  fmt.Sprintf("failed to join room %s%s", room, presence.Error.describe()),
+			}
+		}
+
+		// Status 110 marks our own presence, even if the room changed our nickname
+		if strings.EqualFold(presence.From, occupant) || presence.hasStatus("110") {
+			return nil
+		}
+	}
+}
+
+// handshake upgrades conn to TLS
+func (x *XMPPNotifier) handshake(conn net.Conn) (net.Conn, error) {
+	config := &tls.Config{}
+	if x.tlsConfig != nil {
+		config = x.tlsConfig.Clone()
+	}
+	if config.ServerName == "" {
+		config.ServerName = x.domain
+	}
+
+	tlsConn := tls.Client(conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, &NotificationError{
+			Provider: "xmpp",
+			Message:  "TLS handshake failed",
+			Err:      err,
+		}
+	}
+	return tlsConn, nil
+}
+
+// serverAddress returns the configured server, the SRV target or domain:5222
+func (x *XMPPNotifier) serverAddress(ctx context.Context) string {
+	if x.server != "" {
+		return x.server
+	}
+
+	service := "xmpp-client"
+	if x.directTLS {
+		service = "xmpps-client"
+	}
+	if _, records, err := net.DefaultResolver.LookupSRV(ctx, service, "tcp", x.domain); err == nil && len(records) > 0 {
+		return net.JoinHostPort(strings.TrimSuffix(records[0].Target, "."), strconv.Itoa(int(records[0].Port)))
+	}
+
+	if x.directTLS {
+		return net.JoinHostPort(x.domain, "5223")
+	}
+	return net.JoinHostPort(x.domain, "5222")
+}
+
+// open starts a new stream and returns the advertised features
+func (s *xmppSession) open(domain string) (*xmppFeatures, error) {
+	header := fmt.Sprintf(`<?xml version="1.0"?><stream:stream to="%s" xmlns="%s" xmlns:stream="%s" version="1.0">`,
+		xmppEscape(domain), xmppNSClient, xmppNSStream)
+	if err := s.write(header); err != nil {
+		return nil, err
+	}
+
+	// A new decoder is needed after every stream restart
+	s.decoder = xml.NewDecoder(s.conn)
+
+	element, err := s.next()
+	if err != nil {
+		return nil, err
+	}
+	if element.Name.Space != xmppNSStream || element.Name.Local != "stream" {
+		return nil, &NotificationError{
+			Provider: "xmpp",
+			Message:  fmt.Sprintf("unexpected element <%s> instead of stream header", element.Name.Local),
+		}
+	}
+
+	element, err = s.next()
+	if err != nil {
+		return nil, err
+	}
+	if element.Name.Local != "features" {
+		return nil, &NotificationError{
+			Provider: "xmpp",
+			Message:  fmt.Sprintf("unexpected element <%s> instead of stream features", element.Name.Local),
+		}
+	}
+
+	var features xmppFeatures
+	if err := s.decoder.DecodeElement(&features, &element); err != nil {
+		return nil, s.readError(err)
+	}
+	return &features, nil
+}
+
+// next returns the next start element, failing on stream errors
+func (s *xmppSession) next() (xml.StartElement, error) {
+	for {
+		token, err := s.decoder.Token()
+		if err != nil {
+			return xml.StartElement{}, s.readError(err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Space == xmppNSStream && t.Name.Local == "error" {
+				var streamErr xmppError
+				_ = s.decoder.DecodeElement(&streamErr, &t)
+				return xml.StartElement{}, &NotificationError{
+					Provider: "xmpp",
+					Message:  "stream error" + streamErr.describe(),
+				}
+			}
+			return t, nil
+		case xml.EndElement:
+			if t.Name.Space == xmppNSStream && t.Name.Local == "stream" {
+				return xml.StartElement{}, s.readError(io.EOF)
+			}
+		}
+	}
+}
+
+// write sends raw XML
+func (s *xmppSession) write(data string) error {
+	if _, err := io.WriteString(s.conn, data); err != nil {
+		return &NotificationError{
+			Provider: "xmpp",
+			Message:  "failed to write to stream",
+			Err:      err,
+		}
+	}
+	return nil
+}
+
+// close ends the stream and closes the connection
+func (s *xmppSession) close() {
+	_ = s.write("</stream:stream>")
+	s.conn.Close()
+}
+
+// readError wraps an error reading from the stream
+func (s *xmppSession) readError(err error) error {
+	return &NotificationError{
+		Provider: "xmpp",
+		Message:  "failed to read from stream",
+		Err:      err,
+	}
+}
+
+// hasStatus reports whether the MUC presence carries the given status code
+func (p *xmppPresence) hasStatus(code string) bool {
+	for _, status := range p.Status {
+		if status.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// describe returns the error condition as a message suffix
+func (e *xmppError) describe() string {
+	if e == nil || e.Condition.XMLName.Local == "" {
+		return ""
+	}
+	return ": " + e.Condition.XMLName.Local
+}
+
+// xmppEscape escapes text for use in XML character data and attributes
+func xmppEscape(value string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(value))
+	return sb.String()
+}
+
+// xmppID returns a random stanza ID
+func xmppID() string {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
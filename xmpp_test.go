@@ -0,0 +1,204 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewXMPPNotifier(t *testing.T) {
+	_, err := NewXMPPNotifier(XMPPConfig{JID: "alerts", Password: "secret"})
+	if err == nil {
+		t.Error("Expected error when JID has no domain")
+	}
+
+	_, err = NewXMPPNotifier(XMPPConfig{JID: "alerts@example.com"})
+	if err == nil {
+		t.Error("Expected error when password is missing")
+	}
+
+	notifier, err := NewXMPPNotifier(XMPPConfig{JID: "alerts@example.com", Password: "secret"})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	if notifier.Name() != "xmpp" {
+		t.Errorf("Expected name 'xmpp', got '%s'", notifier.Name())
+	}
+}
+
+type xmppTestMessage struct {
+	To   string `xml:"to,attr"`
+	Type string `xml:"type,attr"`
+	Body string `xml:"body"`
+}
+
+func TestXMPPChatAndGroupchat(t *testing.T) {
+	// Reuse the httptest certificate, which is valid for example.com
+	tlsServer := httptest.NewTLSServer(nil)
+	defer tlsServer.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(tlsServer.Certificate())
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []xmppTestMessage, 1)
+	go func() {
+		defer close(received)
+
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		messages, err := runXMPPTestServer(conn, tlsServer.TLS.Certificates)
+		if err != nil {
+			t.Errorf("Test server failed: %v", err)
+		}
+		received <- messages
+	}()
+
+	notifier, err := NewXMPPNotifier(XMPPConfig{
+		JID:       "alerts@example.com",
+		Password:  "secret",
+		Server:    listener.Addr().String(),
+		TLSConfig: &tls.Config{RootCAs: roots},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create notifier: %v", err)
+	}
+
+	err = notifier.SendWithOptions(context.Background(), &Message{
+		Title:   "Deploy",
+		Text:    "v2 is <live>",
+		Channel: "ops@example.com, muc:team@conference.example.com",
+	})
+	if err != nil {
+		t.Fatalf("SendWithOptions failed: %v", err)
+	}
+
+	messages := <-received
+	if len(messages) != 2 {
+		t.Fatalf("Expected 2 messages, got %d", len(messages))
+	}
+
+	if messages[0].To != "ops@example.com" || messages[0].Type != "chat" || messages[0].Body != "Deploy\nv2 is <live>" {
+		t.Errorf("Unexpected chat message: %+v", messages[0])
+	}
+	if messages[1].To != "team@conference.example.com" || messages[1].Type != "groupchat" {
+		t.Errorf("Unexpected groupchat message: %+v", messages[1])
+	}
+}
+
+// runXMPPTestServer plays the server side of STARTTLS, SASL PLAIN, binding and
+// MUC joins, and returns the messages received before the stream is closed
+func runXMPPTestServer(conn net.Conn, certificates []tls.Certificate) ([]xmppTestMessage, error) {
+	const header = `<?xml version="1.0"?><stream:stream xmlns="jabber:client" xmlns:stream="http://etherx.jabber.org/streams" from="example.com" id="s1" version="1.0">`
+
+	// Each stream restart reads a new client header and advertises the next features
+	restart := func(conn net.Conn, features string) (*xml.Decoder, error) {
+		decoder := xml.NewDecoder(conn)
+		if _, err := xmppTestNext(decoder); err != nil {
+			return nil, err
+		}
+		_, err := io.WriteString(conn, header+"<stream:features>"+features+"</stream:features>")
+		return decoder, err
+	}
+
+	decoder, err := restart(conn, `<starttls xmlns="urn:ietf:params:xml:ns:xmpp-tls"><required/></starttls>`)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := xmppTestNext(decoder); err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(conn, `<proceed xmlns="urn:ietf:params:xml:ns:xmpp-tls"/>`); err != nil {
+		return nil, err
+	}
+
+	tlsConn := tls.Server(conn, &tls.Config{Certificates: certificates})
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+
+	decoder, err = restart(tlsConn, `<mechanisms xmlns="urn:ietf:params:xml:ns:xmpp-sasl"><mechanism>PLAIN</mechanism></mechanisms>`)
+	if err != nil {
+		return nil, err
+	}
+	element, err := xmppTestNext(decoder)
+	if err != nil {
+		return nil, err
+	}
+	var auth struct {
+		Mechanism   string `xml:"mechanism,attr"`
+		Credentials string `xml:",chardata"`
+	}
+	if err := decoder.DecodeElement(&auth, &element); err != nil {
+		return nil, err
+	}
+	credentials, _ := base64.StdEncoding.DecodeString(auth.Credentials)
+	if auth.Mechanism != "PLAIN" || string(credentials) != "\x00alerts\x00secret" {
+		_, _ = io.WriteString(tlsConn, `<failure xmlns="urn:ietf:params:xml:ns:xmpp-sasl"><not-authorized/></failure>`)
+		return nil, io.ErrUnexpectedEOF
+	}
+	if _, err := io.WriteString(tlsConn, `<success xmlns="urn:ietf:params:xml:ns:xmpp-sasl"/>`); err != nil {
+		return nil, err
+	}
+
+	decoder, err = restart(tlsConn, `<bind xmlns="urn:ietf:params:xml:ns:xmpp-bind"/>`)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []xmppTestMessage
+	for {
+		element, err := xmppTestNext(decoder)
+		if err != nil {
+			// The client closed the stream
+			return messages, nil
+		}
+
+		switch element.Name.Local {
+		case "iq":
+			_ = decoder.Skip()
+			_, _ = io.WriteString(tlsConn, `<iq type="result" id="bind"><bind xmlns="urn:ietf:params:xml:ns:xmpp-bind"><jid>alerts@example.com/notify</jid></bind></iq>`)
+		case "presence":
+			var presence struct {
+				To string `xml:"to,attr"`
+			}
+			_ = decoder.DecodeElement(&presence, &element)
+			_, _ = io.WriteString(tlsConn, `<presence from="`+presence.To+`"><x xmlns="http://jabber.org/protocol/muc#user"><status code="110"/></x></presence>`)
+		case "message":
+			var message xmppTestMessage
+			if err := decoder.DecodeElement(&message, &element); err != nil {
+				return nil, err
+			}
+			messages = append(messages, message)
+		default:
+			_ = decoder.Skip()
+		}
+	}
+}
+
+func xmppTestNext(decoder *xml.Decoder) (xml.StartElement, error) {
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		if element, ok := token.(xml.StartElement); ok {
+			return element, nil
+		}
+	}
+}